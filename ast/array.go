@@ -0,0 +1,272 @@
+package ast
+
+import (
+	"aug/interfaces"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ArrayLiteral lists the elements of an array, which are either all integers
+// or all strings. The interpreter also returns it as the value of an array,
+// with the elements being literals.
+type ArrayLiteral struct {
+	Pos
+	Elems []Node
+	// Type is the array type inferred by the Checker.
+	Type Type
+}
+
+func (n *ArrayLiteral) Interpret(i *Interpreter) (Node, error) {
+	elems := make([]Node, len(n.Elems))
+	for idx, elem := range n.Elems {
+		value, err := elem.Interpret(i)
+		if err != nil {
+			return nil, err
+		}
+		elems[idx] = value
+	}
+	return &ArrayLiteral{Elems: elems, Type: n.Type}, nil
+}
+
+func (n *ArrayLiteral) Check(c *Checker) Type {
+	if len(n.Elems) == 0 {
+		// only made by Zero, which knows the type
+		return n.Type
+	}
+	elem := n.Elems[0].Check(c)
+	if elem != INVALID_TYPE && ArrayOf(elem) == INVALID_TYPE {
+		c.Errorf(n.Elems[0].Position(), "unsupported type for array element: %s", elem)
+		elem = INVALID_TYPE
+	}
+	for _, node := range n.Elems[1:] {
+		if elem == INVALID_TYPE {
+			node.Check(c)
+			continue
+		}
+		c.expect(node, elem, "array element")
+	}
+	n.Type = ArrayOf(elem)
+	return n.Type
+}
+
+// IndexNode reads the element of an array at a one-indexed position.
+type IndexNode struct {
+	Pos
+	Array Node
+	Index Node
+	// Type is the type of the element inferred by the Checker.
+	Type Type
+}
+
+func (n *IndexNode) Interpret(i *Interpreter) (Node, error) {
+	array, err := arrayValue(i, n.Array)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := interpretIndex(i, n.Index, len(array.Elems))
+	if err != nil {
+		return nil, err
+	}
+	elem, ok := nodeOf(array.Elems[idx])
+	if !ok {
+		return nil, n.KindErrorf(TypeMismatch, "unsupported type: %v", array.Elems[idx].Type)
+	}
+	return elem, nil
+}
+
+func (n *IndexNode) Check(c *Checker) Type {
+	t := n.Array.Check(c)
+	c.expect(n.Index, INT_TYPE, "array index")
+	if t == INVALID_TYPE {
+		return INVALID_TYPE
+	}
+	if t.Elem() == INVALID_TYPE {
+		c.Errorf(n.Pos, "cannot index %s", t)
+		return INVALID_TYPE
+	}
+	n.Type = t.Elem()
+	return n.Type
+}
+
+// IndexAssignStatNode replaces the element of an array variable at a
+// one-indexed position.
+type IndexAssignStatNode struct {
+	Pos
+	Identifier string
+	Index      Node
+	Value      Node
+}
+
+func (n *IndexAssignStatNode) Interpret(i *Interpreter) (Node, error) {
+	// The value in the table shares its elements with the variable, so they
+	// are changed in place.
+	array, ok := i.VariablesTable.GetValue(n.Identifier)
+	if !ok {
+		return nil, n.KindErrorf(UndefinedVariable, "undefined variable: %s", n.Identifier)
+	}
+	if array.Type != interfaces.ARRAY_VALUE {
+		return nil, n.KindErrorf(TypeMismatch, "cannot index variable %s, it is not an array", n.Identifier)
+	}
+	if i.VariablesTable.IsConstant(n.Identifier) {
+		return nil, n.Errorf("%w %s", interfaces.ErrConstant, n.Identifier)
+	}
+	idx, err := interpretIndex(i, n.Index, len(array.Elems))
+	if err != nil {
+		return nil, err
+	}
+
+	valueNode, err := n.Value.Interpret(i)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := valueOf(valueNode)
+	if !ok || value.Type != array.Elem {
+		return nil, n.KindErrorf(TypeMismatch, "unsupported type for element of array %s: %T", n.Identifier, valueNode)
+	}
+	array.Elems[idx] = value
+	return nil, nil
+}
+
+func (n *IndexAssignStatNode) Check(c *Checker) Type {
+	t, ok := c.scope.lookup(n.Identifier)
+	if !ok {
+		c.Errorf(n.Pos, "undefined variable: %s", n.Identifier)
+		t = INVALID_TYPE
+	} else if c.scope.constant(n.Identifier) {
+		c.Errorf(n.Pos, "cannot assign to constant %s", n.Identifier)
+	}
+	c.expect(n.Index, INT_TYPE, "array index")
+	switch {
+	case t == INVALID_TYPE:
+		n.Value.Check(c)
+	case t.Elem() == INVALID_TYPE:
+		c.Errorf(n.Pos, "cannot index variable %s of type %s", n.Identifier, t)
+		n.Value.Check(c)
+	default:
+		c.expect(n.Value, t.Elem(), "element of array "+n.Identifier)
+	}
+	return VOID_TYPE
+}
+
+// IndexError is the runtime error of an index outside of the bounds of an
+// array, which are 1 to Size.
+type IndexError struct {
+	Index int
+	Size  int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d out of bounds for array of size %d", e.Index, e.Size)
+}
+
+// arrayValue evaluates the node to an array. Variables are read from the
+// table directly, without copying their elements into literals first.
+func arrayValue(i *Interpreter, node Node) (interfaces.Value, error) {
+	if ref, ok := node.(*VariableReferenceNode); ok {
+		value, found := i.VariablesTable.GetValue(ref.Name)
+		if !found {
+			return interfaces.Value{}, ref.KindErrorf(UndefinedVariable, "undefined variable: %s", ref.Name)
+		}
+		if value.Type != interfaces.ARRAY_VALUE {
+			return interfaces.Value{}, ref.KindErrorf(TypeMismatch, "expected array, got variable %s", ref.Name)
+		}
+		return value, nil
+	}
+
+	arrayNode, err := node.Interpret(i)
+	if err != nil {
+		return interfaces.Value{}, err
+	}
+	value, ok := valueOf(arrayNode)
+	if !ok || value.Type != interfaces.ARRAY_VALUE {
+		return interfaces.Value{}, node.Position().KindErrorf(TypeMismatch, "expected array, got %T", arrayNode)
+	}
+	return value, nil
+}
+
+// interpretIndex evaluates the one-indexed position of an element and
+// returns it zero-indexed, once it is known to be within the size.
+func interpretIndex(i *Interpreter, node Node, size int) (int, error) {
+	indexNode, err := node.Interpret(i)
+	if err != nil {
+		return 0, err
+	}
+	index, ok := indexNode.(*NumLiteralNode)
+	if !ok {
+		return 0, node.Position().KindErrorf(TypeMismatch, "expected integer index, got %T", indexNode)
+	}
+	if index.Value < 1 || index.Value > size {
+		return 0, node.Position().Errorf("%w", &IndexError{Index: index.Value, Size: size})
+	}
+	return index.Value - 1, nil
+}
+
+// arrayOf converts an array literal produced by the interpreter into a value.
+func arrayOf(n *ArrayLiteral) (interfaces.Value, bool) {
+	array := interfaces.Value{Type: interfaces.ARRAY_VALUE, Elems: make([]interfaces.Value, len(n.Elems))}
+	switch n.Type {
+	case INT_ARRAY_TYPE:
+		array.Elem = interfaces.INTEGER_VALUE
+	case STRING_ARRAY_TYPE:
+		array.Elem = interfaces.STRING_VALUE
+	default:
+		return interfaces.Value{}, false
+	}
+	for idx, elem := range n.Elems {
+		value, ok := valueOf(elem)
+		if !ok || value.Type != array.Elem {
+			return interfaces.Value{}, false
+		}
+		array.Elems[idx] = value
+	}
+	return array, true
+}
+
+// literalOf converts an array value back into a literal.
+func literalOf(array interfaces.Value) (*ArrayLiteral, bool) {
+	n := &ArrayLiteral{Elems: make([]Node, len(array.Elems))}
+	switch array.Elem {
+	case interfaces.INTEGER_VALUE:
+		n.Type = INT_ARRAY_TYPE
+	case interfaces.STRING_VALUE:
+		n.Type = STRING_ARRAY_TYPE
+	default:
+		return nil, false
+	}
+	for idx, value := range array.Elems {
+		elem, ok := nodeOf(value)
+		if !ok {
+			return nil, false
+		}
+		n.Elems[idx] = elem
+	}
+	return n, true
+}
+
+// FormatArray shows an array the way print does, given its elements as text.
+// Quoted is set for arrays of strings, whose elements are shown as literals.
+func FormatArray(elems []string, quoted bool) string {
+	if quoted {
+		quotedElems := make([]string, len(elems))
+		for idx, elem := range elems {
+			quotedElems[idx] = strconv.Quote(elem)
+		}
+		elems = quotedElems
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// formatLiteral shows an array literal produced by the interpreter.
+func formatLiteral(n *ArrayLiteral) string {
+	elems := make([]string, len(n.Elems))
+	for idx, elem := range n.Elems {
+		switch v := elem.(type) {
+		case *NumLiteralNode:
+			elems[idx] = strconv.Itoa(v.Value)
+		case *StringLiteral:
+			elems[idx] = v.Value
+		}
+	}
+	return FormatArray(elems, n.Type == STRING_ARRAY_TYPE)
+}
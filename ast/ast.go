@@ -2,21 +2,78 @@ package ast
 
 import (
 	"aug/interfaces"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"unicode/utf8"
 )
 
+// Interpreter holds the state of a running program. Without a Context the
+// program runs until it ends, without Stdin and Stdout it uses the ones of
+// the process.
 type Interpreter struct {
 	VariablesTable *interfaces.VariablesTable
+
+	// Context stops the program once it is done, it is checked before every
+	// iteration of a loop and every function call.
+	Context context.Context
+	Stdin   io.Reader
+	Stdout  io.Writer
+	// Overflow is what arithmetic does with results beyond 32 bits.
+	Overflow Overflow
+
+	functions map[string]*function // user-defined functions by name
+	callDepth int                  // number of function calls in progress
+	input     *Input               // reads Stdin, once the program reads it
+}
+
+// interrupted returns the error of the Context once it is done.
+func (i *Interpreter) interrupted(pos Pos) error {
+	if i.Context == nil {
+		return nil
+	}
+	if err := i.Context.Err(); err != nil {
+		return pos.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (i *Interpreter) stdin() io.Reader {
+	if i.Stdin == nil {
+		return os.Stdin
+	}
+	return i.Stdin
+}
+
+// in returns the Input of the program, every read goes through it.
+func (i *Interpreter) in() *Input {
+	if i.input == nil {
+		i.input = NewInput(i.stdin())
+	}
+	return i.input
+}
+
+func (i *Interpreter) stdout() io.Writer {
+	if i.Stdout == nil {
+		return os.Stdout
+	}
+	return i.Stdout
 }
 
 type Node interface {
 	// You might want to define some methods that all nodes must implement.
 	Interpret(*Interpreter) (Node, error)
+	// Check infers the static type of the node, see Checker.
+	Check(*Checker) Type
+	// Position returns where the node starts in the source code.
+	Position() Pos
 }
 
 type NodeSequence struct {
+	Pos
 	Nodes []Node
 }
 
@@ -31,7 +88,15 @@ func (ns *NodeSequence) Interpret(i *Interpreter) (Node, error) {
 	return nil, nil
 }
 
+func (ns *NodeSequence) Check(c *Checker) Type {
+	for _, node := range ns.Nodes {
+		node.Check(c)
+	}
+	return VOID_TYPE
+}
+
 type IfStatNode struct {
+	Pos
 	Condition  Node
 	ThenBranch Node
 	ElseBranch Node
@@ -46,7 +111,7 @@ func (n *IfStatNode) Interpret(i *Interpreter) (Node, error) {
 	conditional, ok := conditionNode.(*BoolLiteral)
 
 	if !ok {
-		return nil, fmt.Errorf("expected conditional to be boolean literal, got %T", conditionNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected conditional to be boolean literal, got %T", conditionNode)
 	}
 
 	var node Node
@@ -60,7 +125,19 @@ func (n *IfStatNode) Interpret(i *Interpreter) (Node, error) {
 	return node, e
 }
 
+func (n *IfStatNode) Check(c *Checker) Type {
+	c.expect(n.Condition, BOOL_TYPE, "if condition")
+	c.condDepth++
+	n.ThenBranch.Check(c)
+	if n.ElseBranch != nil {
+		n.ElseBranch.Check(c)
+	}
+	c.condDepth--
+	return VOID_TYPE
+}
+
 type AssignStatNode struct {
+	Pos
 	Identifier string
 	Value      Node
 }
@@ -72,52 +149,272 @@ func (n *AssignStatNode) Interpret(i *Interpreter) (Node, error) {
 	}
 
 	// Then, assign the result to the variable.
-	var assignError error
-	switch v := valueNode.(type) {
-	case *NumLiteralNode:
-		assignError = i.VariablesTable.SetValue(n.Identifier, interfaces.Value{Type: interfaces.INTEGER_VALUE, Int: v.Value})
-	case *StringLiteral:
-		assignError = i.VariablesTable.SetValue(n.Identifier, interfaces.Value{Type: interfaces.STRING_VALUE, Str: v.Value})
-	// case *VariableReferenceNode:
-	// 	// Assign the value of the referenced variable.
-	// 	value, ok := i.VariablesTable.GetValue(v.Name)
-	// 	if !ok {
-	// 		return nil, fmt.Errorf("undefined variable: %s", v.Name)
-	// 	}
-	// 	i.VariablesTable.SetValue(n.Identifier, value)
-	default:
-		assignError = fmt.Errorf("unsupported type for assignment: %T", v)
+	value, ok := valueOf(valueNode)
+	if !ok {
+		return nil, n.KindErrorf(TypeMismatch, "unsupported type for assignment: %T", valueNode)
 	}
-	return nil, assignError
+	if err := i.VariablesTable.SetValue(n.Identifier, value); err != nil {
+		return nil, assignError(n.Pos, err)
+	}
+	return nil, nil
 }
 
-type PrintStatNode struct {
+// assignError reports why the VariablesTable refused an assignment.
+func assignError(pos Pos, err error) error {
+	switch {
+	case errors.Is(err, interfaces.ErrConstant):
+		return pos.Errorf("%w", err)
+	case errors.Is(err, interfaces.ErrUndeclared):
+		return pos.KindErrorf(UndefinedVariable, "%w", err)
+	}
+	return pos.KindErrorf(TypeMismatch, "%w", err)
+}
+
+func (n *AssignStatNode) Check(c *Checker) Type {
+	t := n.Value.Check(c)
+	if t == VOID_TYPE {
+		c.Errorf(n.Pos, "unsupported type for assignment: %s", t)
+		return VOID_TYPE
+	}
+	c.assign(n.Pos, n.Identifier, t)
+	return VOID_TYPE
+}
+
+// VarDeclNode creates a variable of the current scope, which hides the
+// variables of the same name of the outer scopes. A constant cannot be
+// assigned afterwards.
+type VarDeclNode struct {
+	Pos
+	Identifier string
+	// Type is the declared type of the variable, VOID_TYPE when it is the
+	// type of the Value.
+	Type  Type
 	Value Node
+	Const bool
 }
 
-func (n *PrintStatNode) Interpret(i *Interpreter) (Node, error) {
-	// First interpret the Value node.
+// Zero returns the literal of the value a variable of the given type starts
+// with when it is declared without one.
+func Zero(pos Pos, t Type) Node {
+	switch t {
+	case INT_TYPE:
+		return &NumLiteralNode{Pos: pos}
+	case STRING_TYPE:
+		return &StringLiteral{Pos: pos}
+	case BOOL_TYPE:
+		return &BoolLiteral{Pos: pos}
+	}
+	return &ArrayLiteral{Pos: pos, Type: t}
+}
+
+func (n *VarDeclNode) Interpret(i *Interpreter) (Node, error) {
 	valueNode, err := n.Value.Interpret(i)
 	if err != nil {
 		return nil, err
 	}
+	value, ok := valueOf(valueNode)
+	if !ok {
+		return nil, n.KindErrorf(TypeMismatch, "unsupported type for variable %s: %T", n.Identifier, valueNode)
+	}
+	if n.Const {
+		i.VariablesTable.DeclareConstant(n.Identifier, value)
+	} else {
+		i.VariablesTable.Declare(n.Identifier, value)
+	}
+	return nil, nil
+}
 
-	// Then print the result.
-	switch v := valueNode.(type) {
+func (n *VarDeclNode) Check(c *Checker) Type {
+	t := n.Type
+	if t != VOID_TYPE {
+		c.expect(n.Value, t, "variable "+n.Identifier)
+	} else if t = n.Value.Check(c); t == VOID_TYPE {
+		c.Errorf(n.Pos, "unsupported type for variable %s: %s", n.Identifier, t)
+	}
+	c.declare(n.Pos, n.Identifier, t, n.Const)
+	return VOID_TYPE
+}
+
+// PragmaNode changes how the program is checked and run, it comes before
+// the statements of the program.
+type PragmaNode struct {
+	Pos
+	Name string
+}
+
+func (n *PragmaNode) Interpret(i *Interpreter) (Node, error) {
+	if n.Name == "strict" {
+		i.VariablesTable.Strict = true
+	}
+	return nil, nil
+}
+
+func (n *PragmaNode) Check(c *Checker) Type {
+	if n.Name != "strict" {
+		c.Errorf(n.Pos, "unknown pragma: %s", n.Name)
+		return VOID_TYPE
+	}
+	c.Strict = true
+	return VOID_TYPE
+}
+
+// PrintStatNode prints its values separated by spaces, print ends the line
+// and write doesn't.
+type PrintStatNode struct {
+	Pos
+	Values  []Node
+	Newline bool
+}
+
+func (n *PrintStatNode) Interpret(i *Interpreter) (Node, error) {
+	printed := make([]string, len(n.Values))
+	for idx, value := range n.Values {
+		valueNode, err := value.Interpret(i)
+		if err != nil {
+			return nil, err
+		}
+		arg, ok := printArg(valueNode)
+		if !ok {
+			return nil, n.KindErrorf(TypeMismatch, "unsupported type for print: %T", valueNode)
+		}
+		printed[idx] = fmt.Sprint(arg)
+	}
+
+	line := strings.Join(printed, " ")
+	if n.Newline {
+		line += "\n"
+	}
+	io.WriteString(i.stdout(), line)
+	return nil, nil
+}
+
+func (n *PrintStatNode) Check(c *Checker) Type {
+	for _, value := range n.Values {
+		if t := value.Check(c); t == VOID_TYPE {
+			c.Errorf(value.Position(), "unsupported type for print: %s", t)
+		}
+	}
+	return VOID_TYPE
+}
+
+// printArg converts a value to what fmt prints the way print does, arrays
+// are formatted already.
+func printArg(node Node) (interface{}, bool) {
+	switch v := node.(type) {
 	case *NumLiteralNode:
-		fmt.Println(v.Value)
+		return v.Value, true
 	case *StringLiteral:
-		fmt.Println(v.Value)
+		return v.Value, true
 	case *BoolLiteral:
-		fmt.Println(v.Value)
-	default:
-		return nil, fmt.Errorf("unsupported type for print: %T", v)
+		return v.Value, true
+	case *ArrayLiteral:
+		return formatLiteral(v), true
+	}
+	return nil, false
+}
+
+// PrintfNode prints its arguments in a format, which is checked against
+// them by ParseFormat.
+type PrintfNode struct {
+	Pos
+	Format string
+	Args   []Node
+}
+
+func (n *PrintfNode) Interpret(i *Interpreter) (Node, error) {
+	args := make([]interface{}, len(n.Args))
+	for idx, argNode := range n.Args {
+		valueNode, err := argNode.Interpret(i)
+		if err != nil {
+			return nil, err
+		}
+		arg, ok := printArg(valueNode)
+		if !ok {
+			return nil, n.KindErrorf(TypeMismatch, "unsupported type for printf: %T", valueNode)
+		}
+		args[idx] = arg
 	}
 
+	fmt.Fprintf(i.stdout(), n.Format, args...)
 	return nil, nil
 }
 
+func (n *PrintfNode) Check(c *Checker) Type {
+	verbs, err := ParseFormat(n.Format)
+	if err != nil {
+		c.Errorf(n.Pos, "%s", err)
+	} else if len(verbs) != len(n.Args) {
+		c.Errorf(n.Pos, "printf format has %d verbs, got %d arguments", len(verbs), len(n.Args))
+	}
+
+	for idx, arg := range n.Args {
+		if idx >= len(verbs) {
+			arg.Check(c)
+			continue
+		}
+		if want := VerbType(verbs[idx]); want != VOID_TYPE {
+			c.expect(arg, want, fmt.Sprintf("printf verb %%%c", verbs[idx]))
+		} else if t := arg.Check(c); t == VOID_TYPE {
+			c.Errorf(arg.Position(), "unsupported type for printf: %s", t)
+		}
+	}
+	return VOID_TYPE
+}
+
+// ParseFormat returns the letters of the verbs of a printf format in order.
+// A verb is a % followed by the flags - to align left and 0 to pad with
+// zeros, a width, and d for an int, s for a string, t for a bool or v for a
+// value of any type. %% is a percent sign. The formats are those of the fmt
+// package, widths count characters as runes.
+func ParseFormat(format string) ([]byte, error) {
+	var verbs []byte
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] != '%' {
+			continue
+		}
+		start := idx
+		idx++
+		for idx < len(format) && (format[idx] == '-' || format[idx] == '0') {
+			idx++
+		}
+		for idx < len(format) && format[idx] >= '0' && format[idx] <= '9' {
+			idx++
+		}
+		if idx == len(format) {
+			return nil, fmt.Errorf("printf format ends within the verb %s", format[start:])
+		}
+		switch format[idx] {
+		case 'd', 's', 't', 'v':
+			verbs = append(verbs, format[idx])
+		case '%':
+			if idx == start+1 {
+				break
+			}
+			fallthrough
+		default:
+			_, size := utf8.DecodeRuneInString(format[idx:])
+			return nil, fmt.Errorf("invalid printf verb %s", format[start:idx+size])
+		}
+	}
+	return verbs, nil
+}
+
+// VerbType returns the type of the argument of a verb of ParseFormat, which
+// is VOID_TYPE when it takes any type.
+func VerbType(verb byte) Type {
+	switch verb {
+	case 'd':
+		return INT_TYPE
+	case 's':
+		return STRING_TYPE
+	case 't':
+		return BOOL_TYPE
+	}
+	return VOID_TYPE
+}
+
 type InstrNode struct {
+	Pos
 	Instructions []Node
 }
 
@@ -125,32 +422,78 @@ func (n *InstrNode) Interpret(i *Interpreter) (Node, error) {
 	return nil, nil
 }
 
+func (n *InstrNode) Check(c *Checker) Type {
+	for _, node := range n.Instructions {
+		node.Check(c)
+	}
+	return VOID_TYPE
+}
+
 type VariableReferenceNode struct {
+	Pos
 	Name  string
 	Value *interfaces.Value
+	// Type is the type of the variable, resolved by the Checker.
+	Type Type
 }
 
 func (n *VariableReferenceNode) Interpret(i *Interpreter) (Node, error) {
 	value, ok := i.VariablesTable.GetValue(n.Name)
 	if !ok {
-		return nil, fmt.Errorf("undefined variable: %s", n.Name)
+		return nil, n.KindErrorf(UndefinedVariable, "undefined variable: %s", n.Name)
+	}
+
+	valueNode, ok := nodeOf(value)
+	if !ok {
+		return nil, n.KindErrorf(TypeMismatch, "unsupported type: %v", value.Type)
 	}
 
-	var valueNode Node
+	return valueNode, nil
+}
+
+func (n *VariableReferenceNode) Check(c *Checker) Type {
+	t, ok := c.scope.lookup(n.Name)
+	if !ok {
+		c.Errorf(n.Pos, "undefined variable: %s", n.Name)
+		return INVALID_TYPE
+	}
+	n.Type = t
+	return t
+}
+
+// valueOf converts a literal produced by the interpreter into a value which
+// can be stored in the variables table.
+func valueOf(node Node) (interfaces.Value, bool) {
+	switch v := node.(type) {
+	case *NumLiteralNode:
+		return interfaces.Value{Type: interfaces.INTEGER_VALUE, Int: v.Value}, true
+	case *StringLiteral:
+		return interfaces.Value{Type: interfaces.STRING_VALUE, Str: v.Value}, true
+	case *BoolLiteral:
+		return interfaces.Value{Type: interfaces.BOOLEAN_VALUE, Bool: v.Value}, true
+	case *ArrayLiteral:
+		return arrayOf(v)
+	}
+	return interfaces.Value{}, false
+}
 
+// nodeOf converts a value from the variables table back into a literal.
+func nodeOf(value interfaces.Value) (Node, bool) {
 	switch value.Type {
 	case interfaces.INTEGER_VALUE:
-		valueNode = &NumLiteralNode{Value: value.Int}
+		return &NumLiteralNode{Value: value.Int}, true
 	case interfaces.STRING_VALUE:
-		valueNode = &StringLiteral{Value: value.Str}
-	default:
-		return nil, fmt.Errorf("unsupported type: %T", value.Type)
+		return &StringLiteral{Value: value.Str}, true
+	case interfaces.BOOLEAN_VALUE:
+		return &BoolLiteral{Value: value.Bool}, true
+	case interfaces.ARRAY_VALUE:
+		return literalOf(value)
 	}
-
-	return valueNode, nil
+	return nil, false
 }
 
 type ForStatNode struct {
+	Pos
 	Identifier string
 	Initial    Node
 	Final      Node
@@ -170,16 +513,23 @@ func (n *ForStatNode) Interpret(i *Interpreter) (Node, error) {
 
 	initial, ok := initialNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected initial value to be number literal, got %T", initialNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected initial value to be number literal, got %T", initialNode)
 	}
 
 	final, ok := finalNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected final value to be number literal, got %T", finalNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected final value to be number literal, got %T", finalNode)
 	}
 
-	for value := initial.Value; value <= final.Value; value++ {
-		i.VariablesTable.SetValue(n.Identifier, interfaces.Value{Type: interfaces.INTEGER_VALUE, Int: value})
+	// The counter goes one past the final value, which may be the largest
+	// integer.
+	for value := int64(initial.Value); value <= int64(final.Value); value++ {
+		if err := i.interrupted(n.Pos); err != nil {
+			return nil, err
+		}
+		if err := i.VariablesTable.SetValue(n.Identifier, interfaces.Value{Type: interfaces.INTEGER_VALUE, Int: int(value)}); err != nil {
+			return nil, assignError(n.Pos, err)
+		}
 		_, err := n.Body.Interpret(i)
 		if err != nil {
 			if errors.Is(err, BreakError) {
@@ -187,25 +537,123 @@ func (n *ForStatNode) Interpret(i *Interpreter) (Node, error) {
 			} else if errors.Is(err, ContinueError) {
 				continue
 			} else {
-				return nil, err
+				return nil, traced(err, Frame{Construct: "for", Name: n.Identifier, Counter: int(value), Pos: n.Pos})
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (n *ForStatNode) Check(c *Checker) Type {
+	c.expect(n.Initial, INT_TYPE, "for initial value")
+	c.expect(n.Final, INT_TYPE, "for final value")
+	c.assign(n.Pos, n.Identifier, INT_TYPE)
+
+	c.loopDepth++
+	n.Body.Check(c)
+	c.loopDepth--
+	return VOID_TYPE
+}
+
+type WhileStatNode struct {
+	Pos
+	Condition Node
+	Body      Node
+}
+
+func (n *WhileStatNode) Interpret(i *Interpreter) (Node, error) {
+	for {
+		if err := i.interrupted(n.Pos); err != nil {
+			return nil, err
+		}
+		condition, err := interpretBool(i, n.Condition)
+		if err != nil {
+			return nil, traced(err, Frame{Construct: "while", Pos: n.Pos})
+		}
+		if !condition {
+			break
+		}
+
+		_, err = n.Body.Interpret(i)
+		if err != nil {
+			if errors.Is(err, BreakError) {
+				break
+			} else if errors.Is(err, ContinueError) {
+				continue
+			} else {
+				return nil, traced(err, Frame{Construct: "while", Pos: n.Pos})
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (n *WhileStatNode) Check(c *Checker) Type {
+	c.expect(n.Condition, BOOL_TYPE, "while condition")
+
+	c.loopDepth++
+	n.Body.Check(c)
+	c.loopDepth--
+	return VOID_TYPE
+}
+
+// RepeatStatNode runs its body at least once, until the condition holds.
+type RepeatStatNode struct {
+	Pos
+	Body      Node
+	Condition Node
+}
+
+func (n *RepeatStatNode) Interpret(i *Interpreter) (Node, error) {
+	for {
+		if err := i.interrupted(n.Pos); err != nil {
+			return nil, err
+		}
+		_, err := n.Body.Interpret(i)
+		if err != nil {
+			if errors.Is(err, BreakError) {
+				break
+			} else if !errors.Is(err, ContinueError) {
+				return nil, traced(err, Frame{Construct: "repeat", Pos: n.Pos})
 			}
 		}
+
+		// continue skips the rest of the body, not the condition.
+		condition, err := interpretBool(i, n.Condition)
+		if err != nil {
+			return nil, traced(err, Frame{Construct: "repeat", Pos: n.Pos})
+		}
+		if condition {
+			break
+		}
 	}
 
 	return nil, nil
 }
 
+func (n *RepeatStatNode) Check(c *Checker) Type {
+	c.loopDepth++
+	n.Body.Check(c)
+	c.loopDepth--
+
+	c.expect(n.Condition, BOOL_TYPE, "until condition")
+	return VOID_TYPE
+}
+
 type BlockNode struct {
+	Pos
 	Statements []Node
 }
 
 func (b *BlockNode) Interpret(i *Interpreter) (Node, error) {
-	// Save the old VariablesTable.
+	// Create a new VariablesTable for this block, the old one is restored
+	// whatever way the block ends.
 	oldVariablesTable := i.VariablesTable
-
-	// Create a new VariablesTable for this block.
-	blockVariablesTable := interfaces.MakeChildVariablesTable(*oldVariablesTable)
+	blockVariablesTable := interfaces.MakeBlockVariablesTable(oldVariablesTable)
 	i.VariablesTable = &blockVariablesTable
+	defer func() { i.VariablesTable = oldVariablesTable }()
 
 	// Interpret each statement in the block.
 	var lastNode Node
@@ -213,20 +661,31 @@ func (b *BlockNode) Interpret(i *Interpreter) (Node, error) {
 		var err error
 		lastNode, err = statement.Interpret(i)
 		if err != nil {
-			return nil, err
+			return nil, traced(err, Frame{Construct: "block", Pos: b.Pos})
 		}
 	}
 
-	// Restore the old VariablesTable.
-	i.VariablesTable = oldVariablesTable
-
 	// Return the result of the last statement in the block.
 	return lastNode, nil
 }
 
-type BreakNode struct{}
-type ContinueNode struct{}
-type ExitNode struct{}
+func (b *BlockNode) Check(c *Checker) Type {
+	c.openBlock()
+	for _, statement := range b.Statements {
+		statement.Check(c)
+	}
+	c.closeScope()
+	return VOID_TYPE
+}
+
+type BreakNode struct{ Pos }
+type ContinueNode struct{ Pos }
+
+// ExitNode stops the program, with the exit status 0 unless Status is set.
+type ExitNode struct {
+	Pos
+	Status Node
+}
 
 var BreakError = errors.New("break")
 var ContinueError = errors.New("continue")
@@ -239,7 +698,60 @@ func (n *ContinueNode) Interpret(i *Interpreter) (Node, error) {
 	return nil, ContinueError
 }
 
+func (n *BreakNode) Check(c *Checker) Type {
+	if c.loopDepth == 0 {
+		c.Errorf(n.Pos, "break outside of a loop")
+	}
+	return VOID_TYPE
+}
+
+func (n *ContinueNode) Check(c *Checker) Type {
+	if c.loopDepth == 0 {
+		c.Errorf(n.Pos, "continue outside of a loop")
+	}
+	return VOID_TYPE
+}
+
 func (n *ExitNode) Interpret(i *Interpreter) (Node, error) {
-	os.Exit(0)
-	return nil, nil
+	if n.Status == nil {
+		return nil, &ExitError{}
+	}
+	statusNode, err := n.Status.Interpret(i)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := statusNode.(*NumLiteralNode)
+	if !ok {
+		return nil, n.KindErrorf(TypeMismatch, "expected exit status to be number literal, got %T", statusNode)
+	}
+	if err := CheckExitStatus(status.Value); err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return nil, &ExitError{Status: status.Value}
+}
+
+func (n *ExitNode) Check(c *Checker) Type {
+	if n.Status != nil {
+		c.expect(n.Status, INT_TYPE, "exit status")
+	}
+	return VOID_TYPE
+}
+
+// ExitError is used to unwind the interpreter from an exit statement, up
+// through every loop and function call, carrying the exit status.
+type ExitError struct {
+	Status int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit %d", e.Status)
+}
+
+// CheckExitStatus makes sure that the status of exit is one the operating
+// system can report.
+func CheckExitStatus(status int) error {
+	if status < 0 || status > 255 {
+		return fmt.Errorf("exit status %d out of range 0 to 255", status)
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package ast
 
 type BoolLiteral struct {
+	Pos
 	Value bool
 }
 
@@ -8,7 +9,25 @@ func (n *BoolLiteral) Interpret(i *Interpreter) (Node, error) {
 	return n, nil
 }
 
+func (n *BoolLiteral) Check(c *Checker) Type {
+	return BOOL_TYPE
+}
+
+// EOFNode tells whether the whole input was read.
+type EOFNode struct {
+	Pos
+}
+
+func (n *EOFNode) Interpret(i *Interpreter) (Node, error) {
+	return &BoolLiteral{Value: i.in().EOF()}, nil
+}
+
+func (n *EOFNode) Check(c *Checker) Type {
+	return BOOL_TYPE
+}
+
 type BoolExprNode struct {
+	Pos
 	Op    string
 	Left  Node
 	Right Node
@@ -24,3 +43,79 @@ func (n *BoolExprNode) Interpret(i *Interpreter) (Node, error) {
 
 	return node.Interpret(i)
 }
+
+func (n *BoolExprNode) Check(c *Checker) Type {
+	if n.Op == "==" || n.Op == "!=" {
+		return checkComparison(c, n.Op, n.Left, n.Right, STRING_TYPE)
+	}
+	return checkComparison(c, n.Op, n.Left, n.Right, INT_TYPE)
+}
+
+// LogicalExprNode combines two boolean expressions with "and", "or" or "xor".
+// Operands are evaluated left to right and "and"/"or" short-circuit, so the
+// right operand is only evaluated when it can change the result.
+type LogicalExprNode struct {
+	Pos
+	Op    string
+	Left  Node
+	Right Node
+}
+
+func (n *LogicalExprNode) Interpret(i *Interpreter) (Node, error) {
+	left, err := interpretBool(i, n.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Op {
+	case "and":
+		if !left {
+			return &BoolLiteral{Value: false}, nil
+		}
+	case "or":
+		if left {
+			return &BoolLiteral{Value: true}, nil
+		}
+	case "xor":
+	default:
+		return nil, n.Errorf("logical operation not supported: %s", n.Op)
+	}
+
+	right, err := interpretBool(i, n.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Op == "xor" {
+		return &BoolLiteral{Value: left != right}, nil
+	}
+	return &BoolLiteral{Value: right}, nil
+}
+
+func (n *LogicalExprNode) Check(c *Checker) Type {
+	c.expect(n.Left, BOOL_TYPE, n.Op)
+	c.expect(n.Right, BOOL_TYPE, n.Op)
+	return BOOL_TYPE
+}
+
+// interpretBool interprets the node and unwraps the resulting boolean literal.
+func interpretBool(i *Interpreter, node Node) (bool, error) {
+	valueNode, err := node.Interpret(i)
+	if err != nil {
+		return false, err
+	}
+
+	value, ok := valueNode.(*BoolLiteral)
+	if !ok {
+		return false, node.Position().KindErrorf(TypeMismatch, "expected boolean literal, got %T", valueNode)
+	}
+	return value.Value, nil
+}
+
+// checkComparison checks that both operands of a comparison have the operand
+// type, the comparison itself is always boolean.
+func checkComparison(c *Checker, op string, left, right Node, operand Type) Type {
+	c.expect(left, operand, op)
+	c.expect(right, operand, op)
+	return BOOL_TYPE
+}
@@ -0,0 +1,301 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Builtin is a function of the library, which programs call the same way as
+// user-defined functions. Unlike the built-ins with a syntax of their own its
+// name isn't a keyword, so variables may still be called like it, but
+// functions may not.
+type Builtin struct {
+	Params []Type
+	// Variadic is set when the last parameter repeats, it is given at least
+	// once.
+	Variadic bool
+	Result   Type
+	// Call runs the function on arguments of the types of Params, which are
+	// int, string, bool, []int and []string, and returns a value of the
+	// Result type the same way. Integer results which don't fit do what
+	// overflow says.
+	Call func(args []interface{}, overflow Overflow) (interface{}, error)
+}
+
+// CheckCount fails unless the function called name takes count arguments.
+func (b *Builtin) CheckCount(name string, count int) error {
+	switch {
+	case b.Variadic && count < len(b.Params):
+		return fmt.Errorf("%s expects at least %d arguments, got %d", name, len(b.Params), count)
+	case !b.Variadic && count != len(b.Params):
+		return fmt.Errorf("%s expects %d arguments, got %d", name, len(b.Params), count)
+	}
+	return nil
+}
+
+// param returns the type of the argument at idx.
+func (b *Builtin) param(idx int) Type {
+	if idx >= len(b.Params) {
+		return b.Params[len(b.Params)-1]
+	}
+	return b.Params[idx]
+}
+
+// Builtins are the library functions by name.
+var Builtins = map[string]*Builtin{
+	"upper": {
+		Params: []Type{STRING_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.ToUpper(args[0].(string)), nil
+		},
+	},
+	"lower": {
+		Params: []Type{STRING_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.ToLower(args[0].(string)), nil
+		},
+	},
+	"trim": {
+		Params: []Type{STRING_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.TrimSpace(args[0].(string)), nil
+		},
+	},
+	"reverse": {
+		Params: []Type{STRING_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return ReverseOf(args[0].(string)), nil
+		},
+	},
+	"replace": {
+		Params: []Type{STRING_TYPE, STRING_TYPE, STRING_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.ReplaceAll(args[0].(string), args[1].(string), args[2].(string)), nil
+		},
+	},
+	"split": {
+		Params: []Type{STRING_TYPE, STRING_TYPE},
+		Result: STRING_ARRAY_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return SplitOf(args[0].(string), args[1].(string)), nil
+		},
+	},
+	"join": {
+		Params: []Type{STRING_ARRAY_TYPE, STRING_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.Join(args[0].([]string), args[1].(string)), nil
+		},
+	},
+	"repeat": {
+		Params: []Type{STRING_TYPE, INT_TYPE},
+		Result: STRING_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return RepeatOf(args[0].(string), args[1].(int))
+		},
+	},
+	"startswith": {
+		Params: []Type{STRING_TYPE, STRING_TYPE},
+		Result: BOOL_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.HasPrefix(args[0].(string), args[1].(string)), nil
+		},
+	},
+	"endswith": {
+		Params: []Type{STRING_TYPE, STRING_TYPE},
+		Result: BOOL_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return strings.HasSuffix(args[0].(string), args[1].(string)), nil
+		},
+	},
+	"rposition": {
+		Params: []Type{STRING_TYPE, STRING_TYPE},
+		Result: INT_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return RPositionOf(args[0].(string), args[1].(string)), nil
+		},
+	},
+
+	"abs": {
+		Params: []Type{INT_TYPE},
+		Result: INT_TYPE,
+		Call: func(args []interface{}, overflow Overflow) (interface{}, error) {
+			return Abs(args[0].(int), overflow)
+		},
+	},
+	"min": {
+		Params:   []Type{INT_TYPE},
+		Variadic: true,
+		Result:   INT_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			least := args[0].(int)
+			for _, arg := range args[1:] {
+				if arg.(int) < least {
+					least = arg.(int)
+				}
+			}
+			return least, nil
+		},
+	},
+	"max": {
+		Params:   []Type{INT_TYPE},
+		Variadic: true,
+		Result:   INT_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			greatest := args[0].(int)
+			for _, arg := range args[1:] {
+				if arg.(int) > greatest {
+					greatest = arg.(int)
+				}
+			}
+			return greatest, nil
+		},
+	},
+	"pow": {
+		Params: []Type{INT_TYPE, INT_TYPE},
+		Result: INT_TYPE,
+		Call: func(args []interface{}, overflow Overflow) (interface{}, error) {
+			return Power(args[0].(int), args[1].(int), overflow)
+		},
+	},
+	"isqrt": {
+		Params: []Type{INT_TYPE},
+		Result: INT_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return ISqrt(args[0].(int))
+		},
+	},
+	"sign": {
+		Params: []Type{INT_TYPE},
+		Result: INT_TYPE,
+		Call: func(args []interface{}, _ Overflow) (interface{}, error) {
+			return Sign(args[0].(int)), nil
+		},
+	},
+	"gcd": {
+		Params:   []Type{INT_TYPE, INT_TYPE},
+		Variadic: true,
+		Result:   INT_TYPE,
+		Call: func(args []interface{}, overflow Overflow) (interface{}, error) {
+			values := make([]int, len(args))
+			for idx, arg := range args {
+				values[idx] = arg.(int)
+			}
+			return GCD(values, overflow)
+		},
+	},
+}
+
+// BuiltinNode calls a library function, the parser makes it of the calls of
+// the names in Builtins.
+type BuiltinNode struct {
+	Pos
+	Name string
+	Args []Node
+}
+
+func (n *BuiltinNode) Interpret(i *Interpreter) (Node, error) {
+	b, ok := Builtins[n.Name]
+	if !ok {
+		return nil, n.KindErrorf(UndefinedFunction, "undefined function: %s", n.Name)
+	}
+	if err := b.CheckCount(n.Name, len(n.Args)); err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+
+	args := make([]interface{}, len(n.Args))
+	for idx, arg := range n.Args {
+		argNode, err := arg.Interpret(i)
+		if err != nil {
+			return nil, err
+		}
+		args[idx], ok = builtinArg(argNode)
+		if !ok {
+			return nil, n.KindErrorf(TypeMismatch, "unsupported type for argument: %T", argNode)
+		}
+	}
+
+	result, err := b.Call(args, i.Overflow)
+	if err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return builtinResult(result), nil
+}
+
+func (n *BuiltinNode) Check(c *Checker) Type {
+	b, ok := Builtins[n.Name]
+	if !ok {
+		c.Errorf(n.Pos, "undefined function: %s", n.Name)
+		return INVALID_TYPE
+	}
+	if err := b.CheckCount(n.Name, len(n.Args)); err != nil {
+		for _, arg := range n.Args {
+			arg.Check(c)
+		}
+		c.Errorf(n.Pos, "%s", err)
+		return b.Result
+	}
+	for idx, arg := range n.Args {
+		c.expect(arg, b.param(idx), fmt.Sprintf("argument %d of %s", idx+1, n.Name))
+	}
+	return b.Result
+}
+
+// builtinArg converts a literal produced by the interpreter into an argument
+// of Builtin.Call.
+func builtinArg(node Node) (interface{}, bool) {
+	switch v := node.(type) {
+	case *NumLiteralNode:
+		return v.Value, true
+	case *StringLiteral:
+		return v.Value, true
+	case *BoolLiteral:
+		return v.Value, true
+	case *ArrayLiteral:
+		switch v.Type {
+		case INT_ARRAY_TYPE:
+			elems := make([]int, len(v.Elems))
+			for idx, elem := range v.Elems {
+				elems[idx] = elem.(*NumLiteralNode).Value
+			}
+			return elems, true
+		case STRING_ARRAY_TYPE:
+			elems := make([]string, len(v.Elems))
+			for idx, elem := range v.Elems {
+				elems[idx] = elem.(*StringLiteral).Value
+			}
+			return elems, true
+		}
+	}
+	return nil, false
+}
+
+// builtinResult converts the result of Builtin.Call into a literal.
+func builtinResult(result interface{}) Node {
+	switch v := result.(type) {
+	case int:
+		return &NumLiteralNode{Value: v}
+	case string:
+		return &StringLiteral{Value: v}
+	case bool:
+		return &BoolLiteral{Value: v}
+	case []int:
+		n := &ArrayLiteral{Elems: make([]Node, len(v)), Type: INT_ARRAY_TYPE}
+		for idx, elem := range v {
+			n.Elems[idx] = &NumLiteralNode{Value: elem}
+		}
+		return n
+	case []string:
+		n := &ArrayLiteral{Elems: make([]Node, len(v)), Type: STRING_ARRAY_TYPE}
+		for idx, elem := range v {
+			n.Elems[idx] = &StringLiteral{Value: elem}
+		}
+		return n
+	}
+	return nil
+}
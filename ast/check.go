@@ -0,0 +1,278 @@
+package ast
+
+import "fmt"
+
+// Type is the static type of an expression as inferred by the Checker.
+type Type int
+
+const (
+	VOID_TYPE    Type = iota // statements, which have no value
+	INVALID_TYPE             // expressions that already failed to check
+	INT_TYPE
+	STRING_TYPE
+	BOOL_TYPE
+	INT_ARRAY_TYPE
+	STRING_ARRAY_TYPE
+)
+
+func (t Type) String() string {
+	switch t {
+	case VOID_TYPE:
+		return "void"
+	case INT_TYPE:
+		return "int"
+	case STRING_TYPE:
+		return "string"
+	case BOOL_TYPE:
+		return "bool"
+	case INT_ARRAY_TYPE:
+		return "array of int"
+	case STRING_ARRAY_TYPE:
+		return "array of string"
+	}
+	return "invalid"
+}
+
+// ParseType returns the type a declaration names, which is one of int,
+// string and bool.
+func ParseType(name string) (Type, bool) {
+	switch name {
+	case "int":
+		return INT_TYPE, true
+	case "string":
+		return STRING_TYPE, true
+	case "bool":
+		return BOOL_TYPE, true
+	}
+	return INVALID_TYPE, false
+}
+
+// Elem returns the type of the elements of an array type, and INVALID_TYPE
+// for the other types.
+func (t Type) Elem() Type {
+	switch t {
+	case INT_ARRAY_TYPE:
+		return INT_TYPE
+	case STRING_ARRAY_TYPE:
+		return STRING_TYPE
+	}
+	return INVALID_TYPE
+}
+
+// ArrayOf returns the type of an array of elem, and INVALID_TYPE when there
+// are no arrays of it.
+func ArrayOf(elem Type) Type {
+	switch elem {
+	case INT_TYPE:
+		return INT_ARRAY_TYPE
+	case STRING_TYPE:
+		return STRING_ARRAY_TYPE
+	}
+	return INVALID_TYPE
+}
+
+// CheckError is a semantic error found by the Checker before the program runs.
+type CheckError struct {
+	Msg string
+	Pos Pos
+}
+
+// Error displays the error together with the position it refers to.
+func (e *CheckError) Error() string {
+	return fmt.Sprintf("%s @%s", e.Msg, e.Pos.Location())
+}
+
+// Checker walks the AST and infers the type of every expression. The type of
+// a variable is the type of the first value assigned to it, every later use
+// must agree with it.
+type Checker struct {
+	// Strict requires variables to be declared before they are assigned,
+	// as the strict pragma does.
+	Strict bool
+
+	scope     *checkScope
+	loopDepth int
+	condDepth int // if statements the checked statement is in
+	errors    []error
+
+	functions map[string]*funcInfo // user-defined functions by name
+	declared  []*funcInfo          // user-defined functions in declaration order
+	function  *funcInfo            // function whose body is being checked
+}
+
+// checkScope mirrors the interfaces.VariablesTable scopes the interpreter
+// creates, but records types instead of values.
+type checkScope struct {
+	parent *checkScope
+	vars   map[string]Type
+	consts map[string]bool
+	block  bool
+}
+
+func (s *checkScope) lookup(name string) (Type, bool) {
+	for ; s != nil; s = s.parent {
+		if t, ok := s.vars[name]; ok {
+			return t, true
+		}
+	}
+	return INVALID_TYPE, false
+}
+
+// constant tells whether the variable lookup finds is a constant.
+func (s *checkScope) constant(name string) bool {
+	for ; s != nil; s = s.parent {
+		if _, ok := s.vars[name]; ok {
+			return s.consts[name]
+		}
+	}
+	return false
+}
+
+// Check type checks the program and returns all errors found.
+func Check(program Node) []error {
+	return NewChecker().CheckProgram(program)
+}
+
+// NewChecker returns a Checker with an empty global scope. The variables and
+// functions it learns about are kept between calls to CheckProgram, which lets
+// a program be checked piece by piece, as the REPL does.
+func NewChecker() *Checker {
+	return &Checker{
+		scope:     &checkScope{vars: make(map[string]Type)},
+		functions: make(map[string]*funcInfo),
+	}
+}
+
+// Clone returns a copy of the Checker, which learns about the next part of a
+// program without changing c. The REPL checks its input with a copy, which it
+// only keeps when the input has no errors.
+func (c *Checker) Clone() *Checker {
+	scopes := make(map[*checkScope]*checkScope)
+	var clone func(s *checkScope) *checkScope
+	clone = func(s *checkScope) *checkScope {
+		if s == nil {
+			return nil
+		}
+		if copied, ok := scopes[s]; ok {
+			return copied
+		}
+		copied := &checkScope{parent: clone(s.parent), vars: make(map[string]Type, len(s.vars)), block: s.block}
+		for name, t := range s.vars {
+			copied.vars[name] = t
+		}
+		if s.consts != nil {
+			copied.consts = make(map[string]bool, len(s.consts))
+			for name := range s.consts {
+				copied.consts[name] = true
+			}
+		}
+		scopes[s] = copied
+		return copied
+	}
+
+	copied := &Checker{
+		Strict:    c.Strict,
+		scope:     clone(c.scope),
+		loopDepth: c.loopDepth,
+		condDepth: c.condDepth,
+		functions: make(map[string]*funcInfo, len(c.functions)),
+	}
+	infos := make(map[*funcInfo]*funcInfo)
+	for _, fi := range c.declared {
+		info := *fi
+		info.scope = clone(fi.scope)
+		info.params = append([]Type(nil), fi.params...)
+		infos[fi] = &info
+		copied.declared = append(copied.declared, &info)
+	}
+	for name, fi := range c.functions {
+		copied.functions[name] = infos[fi]
+	}
+	return copied
+}
+
+// CheckProgram type checks the next part of a program and returns the errors
+// found in it.
+func (c *Checker) CheckProgram(program Node) []error {
+	c.errors = nil
+	program.Check(c)
+	c.checkUncalled()
+	c.checkResults()
+	return c.errors
+}
+
+// Errorf records an error at the given position.
+func (c *Checker) Errorf(pos Pos, format string, args ...interface{}) {
+	c.errors = append(c.errors, &CheckError{Msg: fmt.Sprintf(format, args...), Pos: pos})
+}
+
+// expect checks the node and records an error if its type is not the wanted
+// one. Nodes which already failed are not reported again.
+func (c *Checker) expect(node Node, want Type, context string) Type {
+	t := node.Check(c)
+	if t != want && t != INVALID_TYPE {
+		c.Errorf(node.Position(), "%s expected %s, got %s", context, want, t)
+		return INVALID_TYPE
+	}
+	return t
+}
+
+// assign records the type of a variable the same way
+// interfaces.VariablesTable.SetValue does for values, in the scope of the
+// block it is found in or else in the current scope.
+func (c *Checker) assign(pos Pos, name string, t Type) {
+	scope := c.scope
+	for s := c.scope; s != nil; s = s.parent {
+		if _, found := s.vars[name]; found {
+			scope = s
+			break
+		}
+		if !s.block {
+			break
+		}
+	}
+	old, found := scope.vars[name]
+	switch {
+	case !found && c.Strict:
+		// reported once, the variable is known afterwards
+		c.Errorf(pos, "assignment to undeclared variable %s", name)
+	case scope.consts[name]:
+		c.Errorf(pos, "cannot assign to constant %s", name)
+		return
+	}
+	if t == INVALID_TYPE || t == VOID_TYPE {
+		return
+	}
+	if found && old != t && old != INVALID_TYPE {
+		c.Errorf(pos, "cannot change the type of variable %s from %s to %s", name, old, t)
+		return
+	}
+	scope.vars[name] = t
+}
+
+// declare records the type of a variable of the current scope, like
+// interfaces.VariablesTable.Declare and DeclareConstant.
+func (c *Checker) declare(pos Pos, name string, t Type, constant bool) {
+	if _, found := c.scope.vars[name]; found {
+		c.Errorf(pos, "variable %s is already declared in this scope", name)
+		return
+	}
+	if t == VOID_TYPE {
+		t = INVALID_TYPE
+	}
+	c.scope.vars[name] = t
+	if constant {
+		if c.scope.consts == nil {
+			c.scope.consts = make(map[string]bool)
+		}
+		c.scope.consts[name] = true
+	}
+}
+
+func (c *Checker) openBlock() {
+	c.scope = &checkScope{parent: c.scope, vars: make(map[string]Type), block: true}
+}
+
+func (c *Checker) closeScope() {
+	c.scope = c.scope.parent
+}
@@ -0,0 +1,167 @@
+package ast_test
+
+import (
+	"strings"
+	"testing"
+
+	"aug/ast"
+	"aug/lang"
+)
+
+// checkSource parses and checks the program, failing the test on a syntax
+// error.
+func checkSource(t *testing.T, src string) []error {
+	t.Helper()
+	program, errs := lang.Parse(strings.NewReader(src))
+	if len(errs) > 0 {
+		t.Fatalf("parse %q: %v", src, errs)
+	}
+	return ast.Check(program)
+}
+
+// expectError fails the test unless one of the errors contains want, or
+// there are no errors when want is empty.
+func expectError(t *testing.T, errs []error, want string) {
+	t.Helper()
+	if want == "" {
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		return
+	}
+	for _, err := range errs {
+		if strings.Contains(err.Error(), want) {
+			return
+		}
+	}
+	t.Fatalf("errors %v, want %q", errs, want)
+}
+
+func TestCheckMutualRecursion(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string // the error expected, none when empty
+	}{
+		{
+			name: "result of the other function",
+			src: `function a(n) begin if n > 0 then return b(n); return 0; end;
+function b(n) begin return a(n - 1); end;
+print(a(1));
+print(concatenate(b(1), "x"));`,
+			want: "cannot infer result type of b",
+		},
+		{
+			name: "only each other",
+			src: `function a(n) begin return b(n); end;
+function b(n) begin return a(n); end;
+print(a(1));`,
+			want: "cannot infer result type of a",
+		},
+		{
+			name: "base case first",
+			src: `function a(n) begin if n = 0 then return 0; return b(n); end;
+function b(n) begin return a(n - 1); end;
+print(a(1) + b(2));`,
+		},
+		{
+			name: "uncalled",
+			src: `function a(n) begin return b(n); end;
+function b(n) begin return a(n); end;`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expectError(t, checkSource(t, test.src), test.want)
+		})
+	}
+}
+
+func TestCheckTopLevelFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string // the error expected, none when empty
+	}{
+		{
+			name: "top level",
+			src:  `function f(x) begin return x; end; print(f(1));`,
+		},
+		{
+			name: "if",
+			src:  `if false then function f(x) begin return x; end; print(1);`,
+			want: "function f must be declared at the top level of the program",
+		},
+		{
+			name: "else",
+			src:  `if false then print(1) else procedure p() begin print(1); end;`,
+			want: "function p must be declared at the top level of the program",
+		},
+		{
+			name: "loop",
+			src:  `for i := 1 to 2 do function f(x) begin return x; end;`,
+			want: "function f must be declared at the top level of the program",
+		},
+		{
+			name: "block",
+			src:  `begin function f(x) begin return x; end; end;`,
+			want: "function f must be declared at the top level of the program",
+		},
+		{
+			name: "function",
+			src:  `function f(x) begin function g(y) begin return y; end; return x; end;`,
+			want: "function g must be declared outside of other functions",
+		},
+		{
+			name: "repeated parameter",
+			src:  `function f(a, a) begin return a; end; print(f(1, 2));`,
+			want: "parameter a of f is declared twice",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expectError(t, checkSource(t, test.src), test.want)
+		})
+	}
+}
+
+func TestCheckerClone(t *testing.T) {
+	parse := func(src string) ast.Node {
+		program, errs := lang.Parse(strings.NewReader(src))
+		if len(errs) > 0 {
+			t.Fatalf("parse %q: %v", src, errs)
+		}
+		return program
+	}
+
+	c := ast.NewChecker()
+	expectError(t, c.CheckProgram(parse(`x := 1;`)), "")
+
+	// What a copy learns, even from a program it rejects, is not kept.
+	clone := c.Clone()
+	expectError(t, clone.CheckProgram(parse(`y := "a"; function f(n) begin return n; end; print(concatenate(x, y));`)), "concatenate expected string, got int")
+	expectError(t, clone.CheckProgram(parse(`y := 1;`)), "cannot change the type of variable y from string to int")
+
+	expectError(t, c.CheckProgram(parse(`y := 1; function f(n) begin return n; end;`)), "")
+	expectError(t, c.CheckProgram(parse(`x := "a";`)), "cannot change the type of variable x from int to string")
+}
+
+func TestCheckLaterCall(t *testing.T) {
+	c := ast.NewChecker()
+	for _, test := range []struct {
+		src  string
+		want string
+	}{
+		{src: `function f(n) begin return n; end;`},
+		{src: `print(f(1) + 1);`},
+		{src: `print(concatenate(f(1), "a"));`, want: "concatenate expected string, got int"},
+		{src: `function g(n) begin return n; end; function h(n) begin return g(n); end;`},
+		{src: `print(concatenate(h("a"), g("b")));`},
+	} {
+		program, errs := lang.Parse(strings.NewReader(test.src))
+		if len(errs) > 0 {
+			t.Fatalf("parse %q: %v", test.src, errs)
+		}
+		expectError(t, c.CheckProgram(program), test.want)
+	}
+}
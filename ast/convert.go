@@ -0,0 +1,275 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ConversionError is the runtime error of a conversion built-in whose
+// argument can't be converted, such as int("abc") or chr(-1). Func is the
+// name of the built-in.
+type ConversionError struct {
+	Func   string
+	Reason string
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Func, e.Reason)
+}
+
+// StrNode is str(value) or str(value, radix), the digits of an integer.
+type StrNode struct {
+	Pos
+	Value Node
+	Radix Node // nil for base 10
+}
+
+func (n *StrNode) Interpret(i *Interpreter) (Node, error) {
+	args, err := intArgs(i, n.Pos, n.Value, n.Radix)
+	if err != nil {
+		return nil, err
+	}
+	value, err := FormatInt(args[0], radixOf(args))
+	if err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return &StringLiteral{Value: value}, nil
+}
+
+func (n *StrNode) Check(c *Checker) Type {
+	c.expect(n.Value, INT_TYPE, "str")
+	checkRadix(c, n.Radix, "str")
+	return STRING_TYPE
+}
+
+// IntNode is int(str) or int(str, radix), the integer written in a string.
+type IntNode struct {
+	Pos
+	Str   Node
+	Radix Node // nil for base 10
+}
+
+func (n *IntNode) Interpret(i *Interpreter) (Node, error) {
+	str, radix, err := parseArgs(i, n.Pos, n.Str, n.Radix)
+	if err != nil {
+		return nil, err
+	}
+	value, err := ParseIntRadix(str, radix)
+	if err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return &NumLiteralNode{Value: value}, nil
+}
+
+func (n *IntNode) Check(c *Checker) Type {
+	c.expect(n.Str, STRING_TYPE, "int")
+	checkRadix(c, n.Radix, "int")
+	return INT_TYPE
+}
+
+// IsIntNode is isint(str) or isint(str, radix), whether int would convert
+// the string, so that a program can check its input before converting it.
+type IsIntNode struct {
+	Pos
+	Str   Node
+	Radix Node // nil for base 10
+}
+
+func (n *IsIntNode) Interpret(i *Interpreter) (Node, error) {
+	str, radix, err := parseArgs(i, n.Pos, n.Str, n.Radix)
+	if err != nil {
+		return nil, err
+	}
+	value, err := IsInt(str, radix)
+	if err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return &BoolLiteral{Value: value}, nil
+}
+
+func (n *IsIntNode) Check(c *Checker) Type {
+	c.expect(n.Str, STRING_TYPE, "isint")
+	checkRadix(c, n.Radix, "isint")
+	return BOOL_TYPE
+}
+
+// ChrNode is chr(code), the string of the character with the code point.
+type ChrNode struct {
+	Pos
+	Code Node
+}
+
+func (n *ChrNode) Interpret(i *Interpreter) (Node, error) {
+	args, err := intArgs(i, n.Pos, n.Code)
+	if err != nil {
+		return nil, err
+	}
+	value, err := Chr(args[0])
+	if err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return &StringLiteral{Value: value}, nil
+}
+
+func (n *ChrNode) Check(c *Checker) Type {
+	c.expect(n.Code, INT_TYPE, "chr")
+	return STRING_TYPE
+}
+
+// OrdNode is ord(str), the code point of the only character of the string.
+type OrdNode struct {
+	Pos
+	Str Node
+}
+
+func (n *OrdNode) Interpret(i *Interpreter) (Node, error) {
+	strNode, err := n.Str.Interpret(i)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := strNode.(*StringLiteral)
+	if !ok {
+		return nil, n.KindErrorf(TypeMismatch, "expected string literal, got %T", strNode)
+	}
+	value, err := Ord(str.Value)
+	if err != nil {
+		return nil, n.Errorf("%w", err)
+	}
+	return &NumLiteralNode{Value: value}, nil
+}
+
+func (n *OrdNode) Check(c *Checker) Type {
+	c.expect(n.Str, STRING_TYPE, "ord")
+	return INT_TYPE
+}
+
+// intArgs interprets the integer arguments of a built-in, skipping the ones
+// which are nil.
+func intArgs(i *Interpreter, pos Pos, nodes ...Node) ([]int, error) {
+	var values []int
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		value, err := node.Interpret(i)
+		if err != nil {
+			return nil, err
+		}
+		num, ok := value.(*NumLiteralNode)
+		if !ok {
+			return nil, pos.KindErrorf(TypeMismatch, "expected integer literal, got %T", value)
+		}
+		values = append(values, num.Value)
+	}
+	return values, nil
+}
+
+// radixOf returns the radix after the first argument, or 10 without one.
+func radixOf(args []int) int {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return 10
+}
+
+// parseArgs interprets the string and the radix of int and isint.
+func parseArgs(i *Interpreter, pos Pos, strArg, radixArg Node) (string, int, error) {
+	strNode, err := strArg.Interpret(i)
+	if err != nil {
+		return "", 0, err
+	}
+	str, ok := strNode.(*StringLiteral)
+	if !ok {
+		return "", 0, pos.KindErrorf(TypeMismatch, "expected string literal, got %T", strNode)
+	}
+	args, err := intArgs(i, pos, radixArg)
+	if err != nil {
+		return "", 0, err
+	}
+	radix := 10
+	if len(args) > 0 {
+		radix = args[0]
+	}
+	return str.Value, radix, nil
+}
+
+func checkRadix(c *Checker, radix Node, context string) {
+	if radix != nil {
+		c.expect(radix, INT_TYPE, context)
+	}
+}
+
+// Helper functions, they are exported so every backend shares the semantics
+// of the conversion built-ins. Digits above 9 are the letters a to z, int
+// accepts them in either case.
+
+// MinRadix and MaxRadix are the bounds of the radix of a conversion.
+const (
+	MinRadix = 2
+	MaxRadix = 36
+)
+
+func checkRadixValue(fn string, radix int) error {
+	if radix < MinRadix || radix > MaxRadix {
+		return &ConversionError{Func: fn, Reason: fmt.Sprintf("radix %d is not %d to %d", radix, MinRadix, MaxRadix)}
+	}
+	return nil
+}
+
+// FormatInt returns the digits of the integer in the radix, with a minus
+// sign when it is negative.
+func FormatInt(value, radix int) (string, error) {
+	if err := checkRadixValue("str", radix); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(int64(value), radix), nil
+}
+
+// ParseIntRadix reads the integer written in the radix, with an optional
+// sign. Unlike readint it fails with a *ConversionError.
+func ParseIntRadix(s string, radix int) (int, error) {
+	if err := checkRadixValue("int", radix); err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(s, radix, 32)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, &ConversionError{Func: "int", Reason: fmt.Sprintf("%q is out of range", s)}
+	}
+	if err != nil {
+		reason := fmt.Sprintf("%q is not an integer", s)
+		if radix != 10 {
+			reason += fmt.Sprintf(" in base %d", radix)
+		}
+		return 0, &ConversionError{Func: "int", Reason: reason}
+	}
+	return int(value), nil
+}
+
+// IsInt tells whether ParseIntRadix reads the string, it only fails for a
+// radix out of range.
+func IsInt(s string, radix int) (bool, error) {
+	if err := checkRadixValue("isint", radix); err != nil {
+		return false, err
+	}
+	_, err := strconv.ParseInt(s, radix, 32)
+	return err == nil, nil
+}
+
+// Chr returns the character with the code point.
+func Chr(code int) (string, error) {
+	if code < 0 || code > utf8.MaxRune || (code >= 0xD800 && code <= 0xDFFF) {
+		return "", &ConversionError{Func: "chr", Reason: fmt.Sprintf("%d is not a code point", code)}
+	}
+	return string(rune(code)), nil
+}
+
+// Ord returns the code point of the string, which is a single character.
+func Ord(s string) (int, error) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) {
+		return 0, &ConversionError{Func: "ord", Reason: fmt.Sprintf("%q is not one character", s)}
+	}
+	return int(r), nil
+}
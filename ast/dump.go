@@ -0,0 +1,85 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dump writes the tree below the node to w, one node or field per line and
+// indented by depth. It is meant for people debugging the parser.
+func Dump(w io.Writer, node Node) {
+	dump(w, 0, "", reflect.ValueOf(node))
+}
+
+var (
+	nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+	typeType = reflect.TypeOf(VOID_TYPE)
+)
+
+func dump(w io.Writer, depth int, label string, v reflect.Value) {
+	indent := strings.Repeat("  ", depth)
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		fmt.Fprintf(w, "%s%s<nil>\n", indent, label)
+		return
+	}
+
+	node, ok := v.Interface().(Node)
+	if !ok || v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		fmt.Fprintf(w, "%s%s%s\n", indent, label, dumpValue(v))
+		return
+	}
+
+	v = v.Elem()
+	fmt.Fprintf(w, "%s%s%s @%s\n", indent, label, v.Type().Name(), node.Position().Location())
+	for idx := 0; idx < v.NumField(); idx++ {
+		field, value := v.Type().Field(idx), v.Field(idx)
+		if field.Anonymous || field.PkgPath != "" {
+			continue // the Pos, which is already shown, and unexported state
+		}
+		if isUnset(value) {
+			continue
+		}
+		if value.Kind() == reflect.Slice && value.Type().Elem() == nodeType {
+			fmt.Fprintf(w, "%s  %s:\n", indent, field.Name)
+			for elem := 0; elem < value.Len(); elem++ {
+				dump(w, depth+2, fmt.Sprintf("%d: ", elem), value.Index(elem))
+			}
+			continue
+		}
+		dump(w, depth+1, field.Name+": ", value)
+	}
+}
+
+// isUnset tells whether the field is empty, like a missing else branch or a
+// type the Checker didn't resolve yet.
+func isUnset(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+	return v.Type() == typeType && Type(v.Int()) == VOID_TYPE
+}
+
+// dumpValue formats the fields of a node which aren't nodes themselves.
+func dumpValue(v reflect.Value) string {
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Slice:
+		elems := make([]string, v.Len())
+		for idx := range elems {
+			elems[idx] = dumpValue(v.Index(idx))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+	return fmt.Sprint(v.Interface())
+}
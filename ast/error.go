@@ -0,0 +1,167 @@
+package ast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrorKind tells what went wrong in a RuntimeError.
+type ErrorKind int
+
+const (
+	OtherError        ErrorKind = iota
+	TypeMismatch                // a value of the wrong type reached an operation
+	UndefinedVariable           // a variable was read before it was assigned
+	UndefinedFunction           // a function was called before it was declared
+	DivisionByZero              // the wrapped error is a *DivisionByZeroError
+	IndexOutOfBounds            // the wrapped error is an *IndexError
+	IntegerOverflow             // the wrapped error is an *OverflowError
+	InputError                  // reading the input failed or it wasn't valid
+	StackOverflow               // too many nested function calls
+	Interrupted                 // the context of the program is done
+	ConversionFailed            // the wrapped error is a *ConversionError
+)
+
+var errorKindNames = [...]string{
+	OtherError:        "error",
+	TypeMismatch:      "type mismatch",
+	UndefinedVariable: "undefined variable",
+	UndefinedFunction: "undefined function",
+	DivisionByZero:    "division by zero",
+	IndexOutOfBounds:  "index out of bounds",
+	IntegerOverflow:   "integer overflow",
+	InputError:        "input error",
+	StackOverflow:     "stack overflow",
+	Interrupted:       "interrupted",
+	ConversionFailed:  "conversion failed",
+}
+
+func (k ErrorKind) String() string {
+	if int(k) < len(errorKindNames) {
+		return errorKindNames[k]
+	}
+	return fmt.Sprintf("ErrorKind(%d)", k)
+}
+
+// Frame is a construct which the failed node is part of.
+type Frame struct {
+	// Construct is "for", "while", "repeat", "block" or "call".
+	Construct string
+	// Name is the counter of a for loop or the function of a call.
+	Name string
+	// Counter is the value of the counter of a for loop in the iteration
+	// that failed.
+	Counter int
+	// Pos is the span of the construct, for a call it is the call itself.
+	Pos Pos
+}
+
+func (f Frame) String() string {
+	switch f.Construct {
+	case "for":
+		return fmt.Sprintf("for loop, %s = %d", f.Name, f.Counter)
+	case "while", "repeat":
+		return f.Construct + " loop"
+	case "call":
+		return "call of " + f.Name
+	}
+	return f.Construct
+}
+
+// RuntimeError is an error raised while interpreting the node at Pos.
+type RuntimeError struct {
+	Kind ErrorKind
+	Pos  Pos
+	Err  error
+	// Trace lists the constructs the node is part of, the innermost first and
+	// up to the outermost function call.
+	Trace []Frame
+}
+
+// Error displays the error together with the position it happened at.
+func (e *RuntimeError) Error() string {
+	return fmt.Sprintf("%s @%s", e.Err, e.Pos.Location())
+}
+
+// Unwrap returns the underlying error.
+func (e *RuntimeError) Unwrap() error {
+	return e.Err
+}
+
+// reportedFrames is how many frames of each end of a trace Report shows, the
+// ones in between are left out, as deep recursion has thousands of them.
+const reportedFrames = 10
+
+// Report displays the error followed by its trace, a line per frame.
+func (e *RuntimeError) Report() string {
+	var b strings.Builder
+	b.WriteString(e.Error())
+	for idx, frame := range e.Trace {
+		if idx == reportedFrames && len(e.Trace) > 2*reportedFrames {
+			fmt.Fprintf(&b, "\n\t... %d more", len(e.Trace)-2*reportedFrames)
+		}
+		if idx >= reportedFrames && idx < len(e.Trace)-reportedFrames {
+			continue
+		}
+		fmt.Fprintf(&b, "\n\tin %s @%s", frame, frame.Pos.Location())
+	}
+	return b.String()
+}
+
+// Report displays a runtime error with its trace, and any other error as it
+// is.
+func Report(err error) string {
+	var re *RuntimeError
+	if errors.As(err, &re) {
+		return re.Report()
+	}
+	return err.Error()
+}
+
+// Errorf builds an error which happened at this position. Its kind follows
+// from the error it wraps, if any.
+func (p Pos) Errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	return &RuntimeError{Kind: kindOf(err), Pos: p, Err: err}
+}
+
+// KindErrorf is Errorf for an error of the given kind.
+func (p Pos) KindErrorf(kind ErrorKind, format string, args ...interface{}) error {
+	return &RuntimeError{Kind: kind, Pos: p, Err: fmt.Errorf(format, args...)}
+}
+
+func kindOf(err error) ErrorKind {
+	var (
+		divisionErr *DivisionByZeroError
+		indexErr    *IndexError
+		overflowErr *OverflowError
+		convErr     *ConversionError
+	)
+	switch {
+	case errors.As(err, &divisionErr):
+		return DivisionByZero
+	case errors.As(err, &indexErr):
+		return IndexOutOfBounds
+	case errors.As(err, &overflowErr):
+		return IntegerOverflow
+	case errors.As(err, &convErr):
+		return ConversionFailed
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return Interrupted
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return InputError
+	}
+	return OtherError
+}
+
+// traced adds the construct to the trace of a runtime error passing through
+// it. The errors which unwind loops and calls pass unchanged.
+func traced(err error, frame Frame) error {
+	if re, ok := err.(*RuntimeError); ok {
+		re.Trace = append(re.Trace, frame)
+	}
+	return err
+}
@@ -0,0 +1,319 @@
+package ast
+
+import (
+	"aug/interfaces"
+	"errors"
+)
+
+// maxCallDepth limits recursion so runaway programs fail with an error
+// instead of exhausting the Go stack.
+const maxCallDepth = 10000
+
+// FunctionDeclNode declares a function, which returns a value, or a
+// procedure, which doesn't.
+type FunctionDeclNode struct {
+	Pos
+	Name      string
+	Params    []string
+	Body      Node
+	Procedure bool
+
+	// ParamTypes and Result are the signature of the function inferred by
+	// the Checker. The parameters are INVALID_TYPE when it is never called.
+	ParamTypes []Type
+	Result     Type
+}
+
+// function is a user-defined function together with the scope it was
+// declared in. Every call runs in a child of that scope.
+type function struct {
+	decl  *FunctionDeclNode
+	scope *interfaces.VariablesTable
+}
+
+func (n *FunctionDeclNode) Interpret(i *Interpreter) (Node, error) {
+	if i.functions == nil {
+		i.functions = make(map[string]*function)
+	}
+	if _, found := i.functions[n.Name]; found {
+		return nil, n.Errorf("function %s is already declared", n.Name)
+	}
+	i.functions[n.Name] = &function{decl: n, scope: i.VariablesTable}
+	return nil, nil
+}
+
+func (n *FunctionDeclNode) Check(c *Checker) Type {
+	if _, found := c.functions[n.Name]; found {
+		c.Errorf(n.Pos, "function %s is already declared", n.Name)
+		return VOID_TYPE
+	}
+	if _, found := Builtins[n.Name]; found {
+		c.Errorf(n.Pos, "function %s is a built-in", n.Name)
+		return VOID_TYPE
+	}
+	// Functions are declared once, before the program runs, so that every
+	// engine agrees on which are defined: never conditionally, repeatedly or
+	// in a block.
+	switch {
+	case c.function != nil:
+		c.Errorf(n.Pos, "function %s must be declared outside of other functions", n.Name)
+	case c.scope.parent != nil || c.loopDepth > 0 || c.condDepth > 0:
+		c.Errorf(n.Pos, "function %s must be declared at the top level of the program", n.Name)
+	}
+	if param, found := n.RepeatedParam(); found {
+		c.Errorf(n.Pos, "parameter %s of %s is declared twice", param, n.Name)
+	}
+
+	fi := &funcInfo{decl: n, scope: c.scope, ret: INVALID_TYPE}
+	if n.Procedure {
+		fi.ret = VOID_TYPE
+	}
+	c.functions[n.Name] = fi
+	c.declared = append(c.declared, fi)
+	return VOID_TYPE
+}
+
+// RepeatedParam returns the first parameter whose name is already the name
+// of an earlier one. The Checker rejects such functions, the backends refuse
+// them as every parameter needs a variable of its own.
+func (n *FunctionDeclNode) RepeatedParam() (string, bool) {
+	for idx, param := range n.Params {
+		for _, earlier := range n.Params[:idx] {
+			if param == earlier {
+				return param, true
+			}
+		}
+	}
+	return "", false
+}
+
+// funcInfo is what the Checker knows about a user-defined function. The
+// parameter types are inferred from the first call, the return type from the
+// first return statement.
+type funcInfo struct {
+	decl    *FunctionDeclNode
+	scope   *checkScope
+	params  []Type
+	ret     Type
+	checked bool
+	unknown bool // whether it was checked with parameters of unknown type
+	called  bool // whether the program or a called function calls it
+	returns bool // whether a return statement with a value was seen
+}
+
+// checkBody checks the body of the function with the given parameter types.
+func (c *Checker) checkBody(fi *funcInfo, params []Type) {
+	scope, loopDepth, condDepth, current := c.scope, c.loopDepth, c.condDepth, c.function
+	c.scope = &checkScope{parent: fi.scope, vars: make(map[string]Type)}
+	c.loopDepth, c.condDepth = 0, 0
+	c.function = fi
+
+	fi.params = params
+	fi.checked = true
+	for idx, param := range fi.decl.Params {
+		c.scope.vars[param] = params[idx]
+	}
+	fi.decl.Body.Check(c)
+
+	if !fi.returns && !fi.decl.Procedure {
+		c.Errorf(fi.decl.Pos, "function %s never returns a value", fi.decl.Name)
+	}
+	fi.decl.ParamTypes, fi.decl.Result = params, fi.ret
+	c.scope, c.loopDepth, c.condDepth, c.function = scope, loopDepth, condDepth, current
+}
+
+// checkUncalled checks the functions which are never called, their
+// parameters are of unknown type.
+func (c *Checker) checkUncalled() {
+	for _, fi := range c.declared {
+		if !fi.checked {
+			params := make([]Type, len(fi.decl.Params))
+			for idx := range params {
+				params[idx] = INVALID_TYPE
+			}
+			c.checkBody(fi, params)
+			fi.unknown = true
+		}
+	}
+}
+
+// checkResults reports the functions which are called but whose result type
+// is still unknown. That happens when mutually recursive functions only
+// return the results of each other by the time their bodies are checked.
+func (c *Checker) checkResults() {
+	for _, fi := range c.declared {
+		if fi.called && fi.returns && fi.ret == INVALID_TYPE {
+			c.Errorf(fi.decl.Pos, "cannot infer result type of %s", fi.decl.Name)
+		}
+	}
+}
+
+// CallNode calls a user-defined function, either as an expression or, for
+// procedures, as a statement.
+type CallNode struct {
+	Pos
+	Name string
+	Args []Node
+}
+
+func (n *CallNode) Interpret(i *Interpreter) (Node, error) {
+	fn, ok := i.functions[n.Name]
+	if !ok {
+		return nil, n.KindErrorf(UndefinedFunction, "undefined function: %s", n.Name)
+	}
+	if len(n.Args) != len(fn.decl.Params) {
+		return nil, n.Errorf("%s expects %d arguments, got %d", n.Name, len(fn.decl.Params), len(n.Args))
+	}
+	if i.callDepth >= maxCallDepth {
+		return nil, n.KindErrorf(StackOverflow, "call stack exhausted calling %s", n.Name)
+	}
+	if err := i.interrupted(n.Pos); err != nil {
+		return nil, err
+	}
+
+	// Evaluate the arguments in the scope of the caller.
+	frame := interfaces.MakeChildVariablesTable(fn.scope)
+	for idx, arg := range n.Args {
+		argNode, err := arg.Interpret(i)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := valueOf(argNode)
+		if !ok {
+			return nil, n.KindErrorf(TypeMismatch, "unsupported type for argument: %T", argNode)
+		}
+		frame.Declare(fn.decl.Params[idx], value)
+	}
+
+	// Run the body in its own frame, the caller's scope is restored whatever
+	// way the function exits.
+	oldVariablesTable := i.VariablesTable
+	i.VariablesTable = &frame
+	i.callDepth++
+	defer func() {
+		i.VariablesTable = oldVariablesTable
+		i.callDepth--
+	}()
+
+	_, err := fn.decl.Body.Interpret(i)
+	var ret *ReturnError
+	switch {
+	case errors.As(err, &ret):
+		if fn.decl.Procedure {
+			return nil, nil
+		}
+		return ret.Value, nil
+	case errors.Is(err, BreakError), errors.Is(err, ContinueError):
+		return nil, n.Errorf("%s outside of a loop in %s", err, n.Name)
+	case err != nil:
+		return nil, traced(err, Frame{Construct: "call", Name: n.Name, Pos: n.Pos})
+	case !fn.decl.Procedure:
+		return nil, n.Errorf("function %s ended without returning a value", n.Name)
+	}
+	return nil, nil
+}
+
+func (n *CallNode) Check(c *Checker) Type {
+	args := make([]Type, len(n.Args))
+	for idx, arg := range n.Args {
+		args[idx] = arg.Check(c)
+		if args[idx] == VOID_TYPE {
+			c.Errorf(arg.Position(), "unsupported type for argument: %s", args[idx])
+			args[idx] = INVALID_TYPE
+		}
+	}
+
+	fi, ok := c.functions[n.Name]
+	if !ok {
+		c.Errorf(n.Pos, "undefined function: %s", n.Name)
+		return INVALID_TYPE
+	}
+	if len(n.Args) != len(fi.decl.Params) {
+		c.Errorf(n.Pos, "%s expects %d arguments, got %d", n.Name, len(fi.decl.Params), len(n.Args))
+		return fi.ret
+	}
+
+	if c.function == nil || c.function.called {
+		fi.called = true
+	}
+	if fi.unknown && fi.called {
+		// Declared in an earlier part of the program, as in the REPL, and
+		// called for the first time: check it again with known types.
+		fi.unknown, fi.returns = false, false
+		fi.ret = INVALID_TYPE
+		if fi.decl.Procedure {
+			fi.ret = VOID_TYPE
+		}
+		c.checkBody(fi, args)
+		return fi.ret
+	}
+	if !fi.checked {
+		c.checkBody(fi, args)
+		return fi.ret
+	}
+	for idx, t := range args {
+		if t != fi.params[idx] && t != INVALID_TYPE && fi.params[idx] != INVALID_TYPE {
+			c.Errorf(n.Args[idx].Position(), "argument %s of %s expected %s, got %s", fi.decl.Params[idx], n.Name, fi.params[idx], t)
+		}
+	}
+	return fi.ret
+}
+
+// ReturnError is used to unwind the interpreter from a return statement up
+// to the function call, carrying the returned value.
+type ReturnError struct {
+	Value Node
+}
+
+func (e *ReturnError) Error() string {
+	return "return"
+}
+
+// ReturnNode returns from the current function, Value is nil in procedures.
+type ReturnNode struct {
+	Pos
+	Value Node
+}
+
+func (n *ReturnNode) Interpret(i *Interpreter) (Node, error) {
+	if n.Value == nil {
+		return nil, &ReturnError{}
+	}
+
+	valueNode, err := n.Value.Interpret(i)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &ReturnError{Value: valueNode}
+}
+
+func (n *ReturnNode) Check(c *Checker) Type {
+	fi := c.function
+	if fi == nil {
+		c.Errorf(n.Pos, "return outside of a function")
+		if n.Value != nil {
+			n.Value.Check(c)
+		}
+		return VOID_TYPE
+	}
+
+	switch {
+	case n.Value == nil && !fi.decl.Procedure:
+		c.Errorf(n.Pos, "function %s must return a value", fi.decl.Name)
+	case n.Value == nil:
+	case fi.decl.Procedure:
+		n.Value.Check(c)
+		c.Errorf(n.Pos, "procedure %s cannot return a value", fi.decl.Name)
+	case fi.ret == INVALID_TYPE:
+		fi.returns = true
+		if t := n.Value.Check(c); t != VOID_TYPE {
+			fi.ret = t
+		} else if t != INVALID_TYPE {
+			c.Errorf(n.Pos, "unsupported type for return: %s", t)
+		}
+	default:
+		fi.returns = true
+		c.expect(n.Value, fi.ret, "return")
+	}
+	return VOID_TYPE
+}
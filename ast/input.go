@@ -0,0 +1,113 @@
+package ast
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Input is the stream readint, readstr and readln read from. It reads ahead,
+// so a program reads all of its input through the same Input, which every
+// engine keeps for the whole run. At the end of the input the reads fail
+// with an error wrapping io.EOF.
+type Input struct {
+	r *bufio.Reader
+}
+
+// NewInput returns the Input reading from r.
+func NewInput(r io.Reader) *Input {
+	return &Input{r: bufio.NewReader(r)}
+}
+
+// Word reads the next word, which is the text up to a space or a line break,
+// skipping the spaces and line breaks before it. Several words can be on the
+// same line. When only spaces are left on the line of the word, the line
+// break is read too, so that a readln after it reads the next line.
+func (in *Input) Word() (string, error) {
+	r, _, err := in.r.ReadRune()
+	for err == nil && unicode.IsSpace(r) {
+		r, _, err = in.r.ReadRune()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for err == nil && !unicode.IsSpace(r) {
+		b.WriteRune(r)
+		r, _, err = in.r.ReadRune()
+	}
+	if err == nil {
+		in.r.UnreadRune()
+		in.endLine()
+	}
+	return b.String(), nil
+}
+
+// endLine reads up to the next line break when there are only spaces before
+// it.
+func (in *Input) endLine() {
+	for {
+		r, _, err := in.r.ReadRune()
+		if err != nil || r == '\n' {
+			return
+		}
+		if !unicode.IsSpace(r) {
+			in.r.UnreadRune()
+			return
+		}
+	}
+}
+
+// Int reads the next word, which is an integer.
+func (in *Input) Int() (int, error) {
+	word, err := in.Word()
+	if err != nil {
+		return 0, err
+	}
+	return ParseInt(word)
+}
+
+// Line reads the rest of the current line, without the line break. The last
+// line doesn't need one.
+func (in *Input) Line() (string, error) {
+	line, err := in.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// EOF tells whether only spaces and line breaks are left of the input, like
+// seekeof in Pascal, so that a loop reading words until the end doesn't fail
+// on the line break after the last one. The spaces are still there to read,
+// unless there are more than fit into the buffer.
+func (in *Input) EOF() bool {
+	off := 0
+	for {
+		if off+utf8.UTFMax > in.r.Size() {
+			in.r.Discard(off)
+			off = 0
+		}
+		// Peek no further than needed, a terminal only has what was typed.
+		buf, _ := in.r.Peek(off + 1)
+		for len(buf) > off && !utf8.FullRune(buf[off:]) {
+			more, err := in.r.Peek(len(buf) + 1)
+			if err != nil {
+				break
+			}
+			buf = more
+		}
+		if off >= len(buf) {
+			return true
+		}
+		r, size := utf8.DecodeRune(buf[off:])
+		if !unicode.IsSpace(r) {
+			return false
+		}
+		off += size
+	}
+}
@@ -0,0 +1,65 @@
+package ast_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"aug/ast"
+)
+
+func TestInputEOF(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []int
+	}{
+		{name: "empty", input: ""},
+		{name: "no line break", input: "1 2", want: []int{1, 2}},
+		{name: "line break", input: "1\n2\n", want: []int{1, 2}},
+		{name: "blank lines", input: "1\n2\n\n  \n\t\r\n", want: []int{1, 2}},
+		{name: "only blank lines", input: "\n\n \n"},
+		{name: "unicode spaces", input: "1\u00a0\u2028\n", want: []int{1}},
+		{name: "more spaces than the buffer", input: "1" + strings.Repeat(" \n", 5000) + "2\n", want: []int{1, 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := ast.NewInput(strings.NewReader(test.input))
+			var got []int
+			for !in.EOF() {
+				value, err := in.Int()
+				if err != nil {
+					t.Fatalf("after %v: %v", got, err)
+				}
+				got = append(got, value)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("read %v, want %v", got, test.want)
+			}
+			for idx := range got {
+				if got[idx] != test.want[idx] {
+					t.Fatalf("read %v, want %v", got, test.want)
+				}
+			}
+			if _, err := in.Word(); !errors.Is(err, io.EOF) {
+				t.Fatalf("read after the end: %v", err)
+			}
+		})
+	}
+}
+
+func TestInputEOFKeepsLines(t *testing.T) {
+	in := ast.NewInput(strings.NewReader("\n\nlast\n"))
+	var lines []string
+	for !in.EOF() {
+		line, err := in.Line()
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	if strings.Join(lines, "|") != "||last" {
+		t.Fatalf("read %q, want the blank lines before the last one", lines)
+	}
+}
@@ -1,14 +1,68 @@
 package ast
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"math"
 	"strconv"
-	"strings"
 )
 
+// Integers are signed 32-bit values, whatever the size of int on the platform
+// the program runs on.
+const (
+	MinInt = math.MinInt32
+	MaxInt = math.MaxInt32
+)
+
+// Overflow is what integer arithmetic does when the result doesn't fit into
+// 32 bits.
+type Overflow int
+
+const (
+	// TrapOverflow stops the program with an *OverflowError, it is the
+	// default.
+	TrapOverflow Overflow = iota
+	// WrapOverflow keeps the low 32 bits of the result, as two's complement
+	// hardware does.
+	WrapOverflow
+)
+
+// ParseOverflow reads the name of an overflow behaviour, trap or wrap.
+func ParseOverflow(name string) (Overflow, error) {
+	switch name {
+	case "trap":
+		return TrapOverflow, nil
+	case "wrap":
+		return WrapOverflow, nil
+	}
+	return 0, fmt.Errorf("unknown overflow behaviour %s, use trap or wrap", name)
+}
+
+// OverflowError is the runtime error of arithmetic whose result doesn't fit
+// into an integer. Left is unset for the unary minus. For a library function
+// Func is its name instead of Op, and Left and Right are its arguments.
+type OverflowError struct {
+	Op    string
+	Func  string
+	Left  int
+	Right int
+	Unary bool
+}
+
+func (e *OverflowError) Error() string {
+	switch {
+	case e.Func != "" && e.Unary:
+		return fmt.Sprintf("integer overflow: %s(%d)", e.Func, e.Right)
+	case e.Func != "":
+		return fmt.Sprintf("integer overflow: %s(%d, %d)", e.Func, e.Left, e.Right)
+	case e.Unary:
+		return fmt.Sprintf("integer overflow: -(%d)", e.Right)
+	}
+	return fmt.Sprintf("integer overflow: %d %s %d", e.Left, e.Op, e.Right)
+}
+
 type NumLiteralNode struct {
+	Pos
 	Value int
 }
 
@@ -16,7 +70,12 @@ func (n *NumLiteralNode) Interpret(i *Interpreter) (Node, error) {
 	return n, nil
 }
 
+func (n *NumLiteralNode) Check(c *Checker) Type {
+	return INT_TYPE
+}
+
 type NumExprNode struct {
+	Pos
 	Op    string
 	Left  Node
 	Right Node
@@ -37,35 +96,30 @@ func (n *NumExprNode) Interpret(i *Interpreter) (Node, error) {
 	// Ensure that the left and right nodes are string literals.
 	leftStr, ok := leftNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected integer literal, got %T", leftNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected integer literal, got %T", leftNode)
 	}
 
 	rightStr, ok := rightNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected integer literal, got %T", rightNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected integer literal, got %T", rightNode)
 	}
 
-	var value int
-	switch n.Op {
-	case "+":
-		value = leftStr.Value + rightStr.Value
-	case "-":
-		value = leftStr.Value - rightStr.Value
-	case "*":
-		value = leftStr.Value * rightStr.Value
-	case "/":
-		value = leftStr.Value / rightStr.Value
-	case "%":
-		value = leftStr.Value % rightStr.Value
-	default:
-		return nil, fmt.Errorf("integer operation not supported: ", n.Op)
+	value, err := Arithmetic(n.Op, leftStr.Value, rightStr.Value, i.Overflow)
+	if err != nil {
+		return nil, n.Errorf("%w", err)
 	}
 
 	return &NumLiteralNode{Value: value}, nil
+}
 
+func (n *NumExprNode) Check(c *Checker) Type {
+	c.expect(n.Left, INT_TYPE, n.Op)
+	c.expect(n.Right, INT_TYPE, n.Op)
+	return INT_TYPE
 }
 
 type NumComparisonExprNode struct {
+	Pos
 	Op    string
 	Left  Node
 	Right Node
@@ -86,12 +140,12 @@ func (n *NumComparisonExprNode) Interpret(i *Interpreter) (Node, error) {
 	// Ensure that the left and right nodes are string literals.
 	leftStr, ok := leftNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected integer literal, got %T", leftNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected integer literal, got %T", leftNode)
 	}
 
 	rightStr, ok := rightNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected integer literal, got %T", rightNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected integer literal, got %T", rightNode)
 	}
 
 	var value bool
@@ -109,35 +163,39 @@ func (n *NumComparisonExprNode) Interpret(i *Interpreter) (Node, error) {
 	case "<>":
 		value = leftStr.Value != rightStr.Value
 	default:
-		return nil, fmt.Errorf("integer comparison operation not supported: ", n.Op)
+		return nil, n.Errorf("integer comparison operation not supported: %s", n.Op)
 	}
 
 	return &BoolLiteral{Value: value}, nil
 }
 
+func (n *NumComparisonExprNode) Check(c *Checker) Type {
+	return checkComparison(c, n.Op, n.Left, n.Right, INT_TYPE)
+}
+
 type ReadIntNode struct {
+	Pos
 }
 
 func (n *ReadIntNode) Interpret(i *Interpreter) (Node, error) {
-	reader := bufio.NewReader(os.Stdin)
-
-	input, err := reader.ReadString('\n')
+	input, err := i.in().Word()
 	if err != nil {
-		return nil, err
+		return nil, n.KindErrorf(InputError, "readint: %w", err)
 	}
-
-	// Remove the newline character.
-	input = strings.TrimSpace(input)
-	value, err := strconv.Atoi(input)
-
+	value, err := ParseInt(input)
 	if err != nil {
-		return nil, fmt.Errorf("expected integer, but got:", input)
+		return nil, n.KindErrorf(InputError, "%w", err)
 	}
 
 	return &NumLiteralNode{Value: value}, nil
 }
 
+func (n *ReadIntNode) Check(c *Checker) Type {
+	return INT_TYPE
+}
+
 type UnaryOpNode struct {
+	Pos
 	Op      string
 	Operand Node
 }
@@ -151,36 +209,67 @@ func (n *UnaryOpNode) Interpret(i *Interpreter) (Node, error) {
 	var node Node
 	switch v := operandNode.(type) {
 	case *NumLiteralNode:
-		node = &NumLiteralNode{Value: v.Value * -1}
+		value, err := Negate(v.Value, i.Overflow)
+		if err != nil {
+			return nil, n.Errorf("%w", err)
+		}
+		node = &NumLiteralNode{Value: value}
 	case *BoolLiteral:
 		node = &BoolLiteral{Value: !v.Value}
 	default:
-		return nil, fmt.Errorf("unsupported type for unary operation: %T", v)
+		return nil, n.KindErrorf(TypeMismatch, "unsupported type for unary operation: %T", v)
 	}
 
 	return node, nil
 }
 
+func (n *UnaryOpNode) Check(c *Checker) Type {
+	if n.Op == "!" {
+		return c.expect(n.Operand, BOOL_TYPE, "not")
+	}
+	c.expect(n.Operand, INT_TYPE, n.Op)
+	return INT_TYPE
+}
+
 type LengthNode struct {
+	Pos
 	Str Node
 }
 
 func (n *LengthNode) Interpret(i *Interpreter) (Node, error) {
+	if ref, ok := n.Str.(*VariableReferenceNode); ok && ref.Type.Elem() != INVALID_TYPE {
+		// arrays in variables aren't copied just to be counted
+		array, err := arrayValue(i, ref)
+		if err != nil {
+			return nil, err
+		}
+		return &NumLiteralNode{Value: len(array.Elems)}, nil
+	}
+
 	strNode, err := n.Str.Interpret(i)
 	if err != nil {
 		return nil, err
 	}
 
-	str, ok := strNode.(*StringLiteral)
-	if !ok {
-		return nil, fmt.Errorf("length expected string literal, got %T", strNode)
+	switch v := strNode.(type) {
+	case *StringLiteral:
+		return &NumLiteralNode{Value: LengthOf(v.Value)}, nil
+	case *ArrayLiteral:
+		return &NumLiteralNode{Value: len(v.Elems)}, nil
 	}
+	return nil, n.KindErrorf(TypeMismatch, "length expected string or array literal, got %T", strNode)
+}
 
-	return &NumLiteralNode{Value: len(str.Value)}, nil
-
+func (n *LengthNode) Check(c *Checker) Type {
+	t := n.Str.Check(c)
+	if t != STRING_TYPE && t.Elem() == INVALID_TYPE && t != INVALID_TYPE {
+		c.Errorf(n.Str.Position(), "length expected string or array, got %s", t)
+	}
+	return INT_TYPE
 }
 
 type PositionNode struct {
+	Pos
 	Str, Substr Node
 }
 
@@ -197,17 +286,178 @@ func (n *PositionNode) Interpret(i *Interpreter) (Node, error) {
 
 	str, ok := strNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("position expected string literal, got %T", strNode)
+		return nil, n.KindErrorf(TypeMismatch, "position expected string literal, got %T", strNode)
 	}
 
 	sub, ok := subNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("position expected string literal, got %T", subNode)
+		return nil, n.KindErrorf(TypeMismatch, "position expected string literal, got %T", subNode)
 	}
 
-	value := strings.Index(str.Value, sub.Value) + 1
+	return &NumLiteralNode{Value: PositionOf(str.Value, sub.Value)}, nil
+}
 
-	println("POSITION", str.Value, sub.Value, value)
+func (n *PositionNode) Check(c *Checker) Type {
+	c.expect(n.Str, STRING_TYPE, "position")
+	c.expect(n.Substr, STRING_TYPE, "position")
+	return INT_TYPE
+}
 
-	return &NumLiteralNode{Value: value}, nil
+// DivisionByZeroError is the runtime error of dividing by zero, Op is either
+// "/" or "%".
+type DivisionByZeroError struct {
+	Op   string
+	Left int
+}
+
+func (e *DivisionByZeroError) Error() string {
+	return fmt.Sprintf("division by zero: %d %s 0", e.Left, e.Op)
+}
+
+// Arithmetic applies the operator to integers with the 32-bit semantics of the
+// language.
+func Arithmetic(op string, left, right int, overflow Overflow) (int, error) {
+	if (op == "/" || op == "%") && right == 0 {
+		return 0, &DivisionByZeroError{Op: op, Left: left}
+	}
+	a, b := int64(left), int64(right)
+	var value int64
+	switch op {
+	case "+":
+		value = a + b
+	case "-":
+		value = a - b
+	case "*":
+		value = a * b
+	case "/":
+		value = a / b
+	case "%":
+		value = a % b
+	default:
+		return 0, fmt.Errorf("integer operation not supported: %s", op)
+	}
+	if value < MinInt || value > MaxInt {
+		if overflow == TrapOverflow {
+			return 0, &OverflowError{Op: op, Left: left, Right: right}
+		}
+		value = int64(int32(value))
+	}
+	return int(value), nil
+}
+
+// Negate is the unary minus, which overflows for the smallest integer.
+func Negate(value int, overflow Overflow) (int, error) {
+	if value == MinInt {
+		if overflow == TrapOverflow {
+			return 0, &OverflowError{Op: "-", Right: value, Unary: true}
+		}
+		return MinInt, nil
+	}
+	return -value, nil
+}
+
+// Abs returns the absolute value, which overflows for the smallest integer.
+func Abs(value int, overflow Overflow) (int, error) {
+	if value == MinInt && overflow == TrapOverflow {
+		return 0, &OverflowError{Func: "abs", Right: value, Unary: true}
+	}
+	if value < 0 {
+		return Negate(value, overflow)
+	}
+	return value, nil
+}
+
+// Power returns base raised to the power exp, which is not negative.
+func Power(base, exp int, overflow Overflow) (int, error) {
+	if exp < 0 {
+		return 0, fmt.Errorf("pow: negative exponent %d", exp)
+	}
+	// Square and multiply, keeping the low 32 bits of every step, which is
+	// what wrapping gives for the whole power.
+	result, square, overflowed := int64(1), int64(base), false
+	for e := exp; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = wrapped(result*square, &overflowed)
+		}
+		if e > 1 {
+			square = wrapped(square*square, &overflowed)
+		}
+	}
+	if overflowed && overflow == TrapOverflow {
+		return 0, &OverflowError{Func: "pow", Left: base, Right: exp}
+	}
+	return int(result), nil
+}
+
+// wrapped keeps the low 32 bits of value, and records whether that changed
+// it.
+func wrapped(value int64, overflowed *bool) int64 {
+	if value < MinInt || value > MaxInt {
+		*overflowed = true
+		return int64(int32(value))
+	}
+	return value
+}
+
+// ISqrt returns the floor of the square root of the value, which is not
+// negative.
+func ISqrt(value int) (int, error) {
+	if value < 0 {
+		return 0, fmt.Errorf("isqrt: negative argument %d", value)
+	}
+	root := int(math.Sqrt(float64(value)))
+	for root*root > value {
+		root--
+	}
+	for (root+1)*(root+1) <= value {
+		root++
+	}
+	return root, nil
+}
+
+// Sign returns -1, 0 or 1 for a negative, zero or positive value.
+func Sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
+
+// GCD returns the greatest common divisor of the absolute values, of which
+// there are at least two. It is 0 only when they all are, and it overflows
+// when it is 2147483648, which happens for the smallest integer and zeros.
+func GCD(values []int, overflow Overflow) (int, error) {
+	var divisor int64
+	for _, value := range values {
+		x, y := int64(value), divisor
+		if x < 0 {
+			x = -x
+		}
+		for y != 0 {
+			x, y = y, x%y
+		}
+		divisor = x
+	}
+	if divisor > MaxInt {
+		if overflow == TrapOverflow {
+			return 0, &OverflowError{Func: "gcd", Left: values[0], Right: values[1]}
+		}
+		return MinInt, nil
+	}
+	return int(divisor), nil
+}
+
+// ParseInt reads an integer the way readint does.
+func ParseInt(input string) (int, error) {
+	value, err := strconv.ParseInt(input, 10, 32)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("integer out of range: %s", input)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("expected integer, but got: %s", input)
+	}
+	return int(value), nil
 }
@@ -0,0 +1,25 @@
+package ast
+
+import "fmt"
+
+// Pos is the span of a node in the source code. Line and Col are one-indexed
+// and point at the first character of the node, End is the byte offset just
+// past its last character. A zero Pos means that the position is unknown, for
+// example for the values produced while interpreting.
+type Pos struct {
+	File string // empty when the program was not read from a file
+	Line int
+	Col  int
+	End  int
+}
+
+// Position returns the span of the node in the source code.
+func (p Pos) Position() Pos { return p }
+
+// Location formats the position the same way the parser errors do.
+func (p Pos) Location() string {
+	if p.File != "" {
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Col)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Col)
+}
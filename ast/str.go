@@ -1,13 +1,13 @@
 package ast
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 type StrComparisonExprNode struct {
+	Pos
 	Op    string
 	Left  Node
 	Right Node
@@ -28,12 +28,12 @@ func (n *StrComparisonExprNode) Interpret(i *Interpreter) (Node, error) {
 	// Ensure that the left and right nodes are string literals.
 	leftStr, ok := leftNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expected string literal, got %T", leftNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected string literal, got %T", leftNode)
 	}
 
 	rightStr, ok := rightNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expected string literal, got %T", rightNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected string literal, got %T", rightNode)
 	}
 
 	var value bool
@@ -43,13 +43,18 @@ func (n *StrComparisonExprNode) Interpret(i *Interpreter) (Node, error) {
 	case "!=":
 		value = leftStr.Value != rightStr.Value
 	default:
-		return nil, fmt.Errorf("string comparison operation not supported: ", n.Op)
+		return nil, n.Errorf("string comparison operation not supported: %s", n.Op)
 	}
 
 	return &BoolLiteral{Value: value}, nil
 }
 
+func (n *StrComparisonExprNode) Check(c *Checker) Type {
+	return checkComparison(c, n.Op, n.Left, n.Right, STRING_TYPE)
+}
+
 type StringLiteral struct {
+	Pos
 	Value string
 }
 
@@ -57,23 +62,47 @@ func (n *StringLiteral) Interpret(i *Interpreter) (Node, error) {
 	return n, nil
 }
 
+func (n *StringLiteral) Check(c *Checker) Type {
+	return STRING_TYPE
+}
+
 type ReadStr struct {
+	Pos
 }
 
 func (n *ReadStr) Interpret(i *Interpreter) (Node, error) {
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	input, err := i.in().Word()
 	if err != nil {
-		return nil, err
+		return nil, n.KindErrorf(InputError, "readstr: %w", err)
 	}
 
-	// Remove the newline character.
-	input = strings.TrimSpace(input)
+	return &StringLiteral{Value: input}, nil
+}
+
+func (n *ReadStr) Check(c *Checker) Type {
+	return STRING_TYPE
+}
+
+// ReadLn reads the rest of the current line of input.
+type ReadLn struct {
+	Pos
+}
+
+func (n *ReadLn) Interpret(i *Interpreter) (Node, error) {
+	input, err := i.in().Line()
+	if err != nil {
+		return nil, n.KindErrorf(InputError, "readln: %w", err)
+	}
 
 	return &StringLiteral{Value: input}, nil
 }
 
+func (n *ReadLn) Check(c *Checker) Type {
+	return STRING_TYPE
+}
+
 type Concatenate struct {
+	Pos
 	Left, Right Node
 }
 
@@ -92,19 +121,26 @@ func (n *Concatenate) Interpret(i *Interpreter) (Node, error) {
 	// Ensure that the left and right nodes are string literals.
 	leftStr, ok := leftNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expected string literal, got %T", leftNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected string literal, got %T", leftNode)
 	}
 
 	rightStr, ok := rightNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expected string literal, got %T", rightNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected string literal, got %T", rightNode)
 	}
 
 	// Concatenate the strings and return a new string literal.
 	return &StringLiteral{Value: leftStr.Value + rightStr.Value}, nil
 }
 
+func (n *Concatenate) Check(c *Checker) Type {
+	c.expect(n.Left, STRING_TYPE, "concatenate")
+	c.expect(n.Right, STRING_TYPE, "concatenate")
+	return STRING_TYPE
+}
+
 type Substring struct {
+	Pos
 	Str           Node
 	Start, Length Node
 }
@@ -129,25 +165,58 @@ func (n *Substring) Interpret(i *Interpreter) (Node, error) {
 	// Ensure that the Str node is string literals.
 	str, ok := strNode.(*StringLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expected string literal, got %T", strNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected string literal, got %T", strNode)
 	}
 
 	start, ok := startNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected integer literal, got %T", startNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected integer literal, got %T", startNode)
 	}
 
 	length, ok := lengthNode.(*NumLiteralNode)
 	if !ok {
-		return nil, fmt.Errorf("expected string literal, got %T", lengthNode)
+		return nil, n.KindErrorf(TypeMismatch, "expected integer literal, got %T", lengthNode)
 	}
 
-	return &StringLiteral{Value: substring(str.Value, start.Value, length.Value)}, nil
+	return &StringLiteral{Value: SubstringOf(str.Value, start.Value, length.Value)}, nil
+}
+
+func (n *Substring) Check(c *Checker) Type {
+	c.expect(n.Str, STRING_TYPE, "substring")
+	c.expect(n.Start, INT_TYPE, "substring")
+	c.expect(n.Length, INT_TYPE, "substring")
+	return STRING_TYPE
 }
 
-// Helper functions
-func substring(string1 string, pos, length int) string {
-	strLen := len(string1)
+// Helper functions, they are exported so every backend shares the semantics
+// of the string built-ins.
+
+// The characters of a string are its Unicode code points (runes), not its
+// bytes, nor its graphemes: "\u{65}\u{301}" is a single é on screen but two
+// characters long. Counting graphemes would need the segmentation rules of
+// Unicode, code points are what the \u{...} escapes write. Bytes which are
+// not valid UTF-8 count as a character each.
+
+// LengthOf returns the number of characters of the string.
+func LengthOf(string1 string) int {
+	return utf8.RuneCountInString(string1)
+}
+
+// PositionOf returns the one-indexed position of the character the first
+// occurrence of sub in the string starts at, or 0 when it doesn't occur.
+func PositionOf(string1, sub string) int {
+	idx := strings.Index(string1, sub)
+	if idx < 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(string1[:idx]) + 1
+}
+
+// SubstringOf returns length characters of the string starting at the
+// one-indexed pos, cut short at the end of the string.
+func SubstringOf(string1 string, pos, length int) string {
+	runes := []rune(string1)
+	strLen := len(runes)
 
 	if pos < 1 || pos > strLen || length <= 0 {
 		return ""
@@ -158,5 +227,42 @@ func substring(string1 string, pos, length int) string {
 		end = strLen
 	}
 
-	return string1[pos-1 : end]
+	return string(runes[pos-1 : end])
+}
+
+// RPositionOf returns the one-indexed position of the character the last
+// occurrence of sub in the string starts at, or 0 when it doesn't occur.
+func RPositionOf(string1, sub string) int {
+	idx := strings.LastIndex(string1, sub)
+	if idx < 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(string1[:idx]) + 1
+}
+
+// ReverseOf returns the characters of the string in reverse order.
+func ReverseOf(string1 string) string {
+	runes := []rune(string1)
+	for left, right := 0, len(runes)-1; left < right; left, right = left+1, right-1 {
+		runes[left], runes[right] = runes[right], runes[left]
+	}
+	return string(runes)
+}
+
+// SplitOf returns the parts of the string between the separators, or its
+// characters when the separator is empty.
+func SplitOf(string1, sep string) []string {
+	return strings.Split(string1, sep)
+}
+
+// RepeatOf returns the string count times, which is empty when count isn't
+// positive. The result can't be longer than the largest integer.
+func RepeatOf(string1 string, count int) (string, error) {
+	if count <= 0 {
+		return "", nil
+	}
+	if LengthOf(string1) > MaxInt/count {
+		return "", fmt.Errorf("repeat: the result would be longer than %d characters", MaxInt)
+	}
+	return strings.Repeat(string1, count), nil
 }
@@ -0,0 +1,952 @@
+// Package emit translates AUG programs into the source code of other
+// languages, so they can be built with their own toolchains.
+package emit
+
+import (
+	"aug/ast"
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// variable is an AUG variable of one scope, which becomes a Go variable.
+// Variables that might be read before they are assigned get a second Go
+// variable, which tells whether they were.
+type variable struct {
+	name   string // AUG name
+	goName string
+	typ    ast.Type
+	set    string // name of the Go variable telling if it was assigned, if any
+	read   bool
+	param  bool
+}
+
+// scope mirrors the interfaces.VariablesTable scopes of the interpreter. Like
+// in vm.Compile, a variable is visible from its first assignment on, except
+// in function bodies, which see every variable of the scope they were
+// declared in.
+type scope struct {
+	parent   *scope
+	vars     map[string]*variable // the variables assigned so far
+	all      map[string]*variable // every variable assigned in the scope
+	order    []*variable          // every variable, as they are declared
+	function bool                 // whether this is the scope of a function body
+	block    bool                 // whether this is the scope of a block
+}
+
+// binding finds the variable an assignment in the scope writes to, which
+// may be one of the scopes of the blocks it is in, like
+// interfaces.VariablesTable.SetValue. It is nil for a new variable.
+func binding(s *scope, name string) *variable {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v
+		}
+		if !s.block {
+			break
+		}
+	}
+	return nil
+}
+
+type generator struct {
+	out   *bytes.Buffer
+	scope *scope
+
+	functions  map[string]*ast.FunctionDeclNode
+	called     map[string]bool // the functions called by the translated code
+	names      map[string]int  // how many Go variables were named after an AUG name
+	unsafe     map[string]bool // the names read by function bodies
+	inFunction bool
+}
+
+// Go translates a type checked AST into a standalone Go program, which only
+// depends on the standard library. Its arithmetic treats results beyond 32
+// bits the way overflow says.
+func Go(program ast.Node, overflow ast.Overflow) ([]byte, error) {
+	g := &generator{
+		out:       &bytes.Buffer{},
+		functions: make(map[string]*ast.FunctionDeclNode),
+		called:    make(map[string]bool),
+		names:     make(map[string]int),
+		unsafe:    make(map[string]bool),
+	}
+	g.declarations(program, false)
+	for _, decl := range g.sortedFunctions() {
+		if !decl.Procedure && decl.Result == ast.INVALID_TYPE {
+			return nil, fmt.Errorf("emit: cannot infer result type of %s @%s", decl.Name, decl.Pos.Location())
+		}
+		if param, found := decl.RepeatedParam(); found {
+			return nil, fmt.Errorf("emit: parameter %s of %s is declared twice @%s", param, decl.Name, decl.Pos.Location())
+		}
+	}
+	if err := g.block(statementsOf(program), nil); err != nil {
+		return nil, err
+	}
+	body := g.out
+
+	source := program.Position().File
+	if source == "" {
+		source = "standard input"
+	}
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by the AUG compiler from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprint(out, "package main\n\nimport (\n\"bufio\"\n\"errors\"\n\"fmt\"\n\"io\"\n\"math\"\n\"os\"\n\"strconv\"\n\"strings\"\n\"unicode\"\n\"unicode/utf8\"\n)\n\n")
+
+	// Functions are global, whichever scope they are declared in. They are
+	// nil until the declaration runs, calls get them through get_, which
+	// fails like the interpreter does before then.
+	fmt.Fprint(out, "func run() {\n")
+	for _, decl := range g.sortedFunctions() {
+		fmt.Fprintf(out, "var f_%s %s\n", decl.Name, signature(decl))
+		fmt.Fprintf(out, "get_%s := func(at string) %s {\n", decl.Name, signature(decl))
+		fmt.Fprintf(out, "if f_%s == nil {\nfail(%q, at)\n}\n", decl.Name, "undefined function: "+decl.Name)
+		fmt.Fprintf(out, "return f_%s\n}\n", decl.Name)
+		if !g.called[decl.Name] {
+			fmt.Fprintf(out, "_ = get_%s\n", decl.Name)
+		}
+	}
+	out.Write(body.Bytes())
+	fmt.Fprint(out, "}\n")
+	out.WriteString(runtime)
+	fmt.Fprintf(out, "\nconst wrapOverflow = %t\n", overflow == ast.WrapOverflow)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("emit: generated invalid Go code: %w", err)
+	}
+	return src, nil
+}
+
+// declarations collects the functions which are called, and the names of
+// the variables their bodies read.
+func (g *generator) declarations(node ast.Node, inFunction bool) {
+	switch n := node.(type) {
+	case *ast.FunctionDeclNode:
+		if emitted(n) {
+			g.functions[n.Name] = n
+			g.declarations(n.Body, true)
+		}
+	case *ast.VariableReferenceNode:
+		if inFunction {
+			g.unsafe[n.Name] = true
+		}
+	case *ast.IndexAssignStatNode:
+		if inFunction {
+			g.unsafe[n.Identifier] = true
+		}
+		for _, child := range children(node) {
+			g.declarations(child, inFunction)
+		}
+	case *ast.NodeSequence:
+		for _, statement := range n.Nodes {
+			g.declarations(statement, inFunction)
+		}
+	case *ast.BlockNode:
+		for _, statement := range n.Statements {
+			g.declarations(statement, inFunction)
+		}
+	default:
+		for _, child := range children(node) {
+			g.declarations(child, inFunction)
+		}
+	}
+}
+
+// withRadix returns the arguments of a conversion, the radix is optional.
+func withRadix(value, radix ast.Node) []ast.Node {
+	if radix == nil {
+		return []ast.Node{value}
+	}
+	return []ast.Node{value, radix}
+}
+
+// emitted tells whether the function is translated, which only happens when
+// the Checker could infer its parameters from a call. Go refuses the
+// functions whose result is still unknown.
+func emitted(decl *ast.FunctionDeclNode) bool {
+	for _, t := range decl.ParamTypes {
+		if t == ast.INVALID_TYPE {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *generator) sortedFunctions() []*ast.FunctionDeclNode {
+	var decls []*ast.FunctionDeclNode
+	for _, decl := range g.functions {
+		decls = append(decls, decl)
+	}
+	sort.Slice(decls, func(i, j int) bool { return decls[i].Name < decls[j].Name })
+	return decls
+}
+
+// signature returns the Go type of the function. Every function gets the
+// location of the call as its first argument, for the runtime errors.
+func signature(decl *ast.FunctionDeclNode) string {
+	params := "at string"
+	for idx, param := range decl.Params {
+		params += fmt.Sprintf(", v_%s %s", param, goType(decl.ParamTypes[idx]))
+	}
+	if decl.Procedure {
+		return fmt.Sprintf("func(%s)", params)
+	}
+	return fmt.Sprintf("func(%s) %s", params, goType(decl.Result))
+}
+
+func goType(t ast.Type) string {
+	switch t {
+	case ast.INT_TYPE:
+		return "int"
+	case ast.STRING_TYPE:
+		return "string"
+	case ast.BOOL_TYPE:
+		return "bool"
+	case ast.INT_ARRAY_TYPE:
+		return "[]int"
+	case ast.STRING_ARRAY_TYPE:
+		return "[]string"
+	}
+	return "interface{}"
+}
+
+func statementsOf(node ast.Node) []ast.Node {
+	switch n := node.(type) {
+	case *ast.NodeSequence:
+		return n.Nodes
+	case *ast.BlockNode:
+		return n.Statements
+	}
+	return []ast.Node{node}
+}
+
+// children returns the nodes below the statement or expression which don't
+// start a new scope.
+func children(node ast.Node) []ast.Node {
+	switch n := node.(type) {
+	case *ast.NodeSequence:
+		return n.Nodes
+	case *ast.IfStatNode:
+		if n.ElseBranch != nil {
+			return []ast.Node{n.Condition, n.ThenBranch, n.ElseBranch}
+		}
+		return []ast.Node{n.Condition, n.ThenBranch}
+	case *ast.ForStatNode:
+		return []ast.Node{n.Initial, n.Final, n.Body}
+	case *ast.WhileStatNode:
+		return []ast.Node{n.Condition, n.Body}
+	case *ast.RepeatStatNode:
+		return []ast.Node{n.Body, n.Condition}
+	case *ast.AssignStatNode:
+		return []ast.Node{n.Value}
+	case *ast.VarDeclNode:
+		return []ast.Node{n.Value}
+	case *ast.PrintStatNode:
+		return n.Values
+	case *ast.PrintfNode:
+		return n.Args
+	case *ast.ReturnNode:
+		if n.Value != nil {
+			return []ast.Node{n.Value}
+		}
+	case *ast.CallNode:
+		return n.Args
+	case *ast.BuiltinNode:
+		return n.Args
+	case *ast.NumExprNode:
+		return []ast.Node{n.Left, n.Right}
+	case *ast.BoolExprNode:
+		return []ast.Node{n.Left, n.Right}
+	case *ast.NumComparisonExprNode:
+		return []ast.Node{n.Left, n.Right}
+	case *ast.StrComparisonExprNode:
+		return []ast.Node{n.Left, n.Right}
+	case *ast.LogicalExprNode:
+		return []ast.Node{n.Left, n.Right}
+	case *ast.UnaryOpNode:
+		return []ast.Node{n.Operand}
+	case *ast.LengthNode:
+		return []ast.Node{n.Str}
+	case *ast.PositionNode:
+		return []ast.Node{n.Str, n.Substr}
+	case *ast.Concatenate:
+		return []ast.Node{n.Left, n.Right}
+	case *ast.Substring:
+		return []ast.Node{n.Str, n.Start, n.Length}
+	case *ast.StrNode:
+		return withRadix(n.Value, n.Radix)
+	case *ast.IntNode:
+		return withRadix(n.Str, n.Radix)
+	case *ast.IsIntNode:
+		return withRadix(n.Str, n.Radix)
+	case *ast.ChrNode:
+		return []ast.Node{n.Code}
+	case *ast.OrdNode:
+		return []ast.Node{n.Str}
+	case *ast.ArrayLiteral:
+		return n.Elems
+	case *ast.IndexNode:
+		return []ast.Node{n.Array, n.Index}
+	case *ast.IndexAssignStatNode:
+		return []ast.Node{n.Index, n.Value}
+	case *ast.BlockNode:
+		return n.Statements
+	}
+	return nil
+}
+
+// block translates the statements of a scope, which is a new one unless s is
+// given. The Go variables of the scope are declared at its start, because
+// the first assignment of an AUG variable can be nested in an if or a loop.
+func (g *generator) block(statements []ast.Node, s *scope) error {
+	if s == nil {
+		s = &scope{vars: make(map[string]*variable), all: make(map[string]*variable)}
+	}
+	s.parent = g.scope
+	for _, statement := range statements {
+		g.collect(s, statement, true)
+	}
+
+	out := g.out
+	g.out, g.scope = &bytes.Buffer{}, s
+	for _, statement := range statements {
+		if err := g.statement(statement); err != nil {
+			return err
+		}
+	}
+	body := g.out
+	g.out, g.scope = out, s.parent
+
+	for _, v := range s.order {
+		if v.param {
+			continue // declared by the function
+		}
+		fmt.Fprintf(g.out, "var %s %s\n", v.goName, goType(v.typ))
+		if v.set != "" {
+			fmt.Fprintf(g.out, "var %s bool\n", v.set)
+		}
+		if !v.read {
+			fmt.Fprintf(g.out, "_ = %s\n", v.goName)
+			if v.set != "" {
+				fmt.Fprintf(g.out, "_ = %s\n", v.set)
+			}
+		}
+	}
+	g.out.Write(body.Bytes())
+	return nil
+}
+
+// collect creates the variables assigned in the scope, unless the scope is a
+// block and they belong to the scope around it. A variable needs to track
+// whether it is assigned unless a statement of the scope itself assigns it
+// first, and it is not read by a function, which may run at any time.
+func (g *generator) collect(s *scope, node ast.Node, direct bool) {
+	var name string
+	var typ ast.Type
+	declared := false
+	switch n := node.(type) {
+	case *ast.BlockNode, *ast.FunctionDeclNode:
+		return // these have scopes of their own
+	case *ast.AssignStatNode:
+		name, typ = n.Identifier, typeOf(n.Value, g.functions)
+	case *ast.VarDeclNode:
+		name, typ, declared = n.Identifier, typeOf(n.Value, g.functions), true
+	case *ast.ForStatNode:
+		name, typ, direct = n.Identifier, ast.INT_TYPE, false
+	}
+
+	if name != "" && (declared || binding(s.parent, name) == nil || !s.block) {
+		if _, found := s.all[name]; !found {
+			v := &variable{name: name, goName: g.goName("v", name), typ: typ}
+			if !direct || (g.unsafe[name] && !g.inFunction) {
+				v.set = g.goName("set", name)
+			}
+			s.all[name] = v
+			s.order = append(s.order, v)
+		}
+	}
+	for _, child := range children(node) {
+		g.collect(s, child, false)
+	}
+}
+
+// goName returns a new Go name for the AUG name. The prefixes keep them
+// apart from Go keywords and from the runtime.
+func (g *generator) goName(prefix, name string) string {
+	key := prefix + "_" + name
+	g.names[key]++
+	if count := g.names[key]; count > 1 {
+		return fmt.Sprintf("%s%d_%s", prefix, count, name)
+	}
+	return key
+}
+
+// lookup finds the variable a name refers to at this point of the program.
+func (g *generator) lookup(name string) *variable {
+	complete := false
+	for s := g.scope; s != nil; s = s.parent {
+		vars := s.vars
+		if complete {
+			vars = s.all
+		}
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		complete = complete || s.function
+	}
+	return nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.out, format, args...)
+}
+
+func (g *generator) statement(node ast.Node) error {
+	switch n := node.(type) {
+	case *ast.NodeSequence:
+		for _, statement := range n.Nodes {
+			if err := g.statement(statement); err != nil {
+				return err
+			}
+		}
+
+	case *ast.BlockNode:
+		g.printf("{\n")
+		g.printf("t := push(\"block\", %q, false)\n", n.Pos.Location())
+		s := &scope{vars: make(map[string]*variable), all: make(map[string]*variable), block: true}
+		if err := g.block(n.Statements, s); err != nil {
+			return err
+		}
+		g.printf("trace = trace[:t]\n")
+		g.printf("}\n")
+
+	case *ast.AssignStatNode:
+		value, err := g.value(n.Value)
+		if err != nil {
+			return err
+		}
+		g.assign(n.Identifier, value)
+
+	case *ast.VarDeclNode:
+		value, err := g.value(n.Value)
+		if err != nil {
+			return err
+		}
+		g.scope.vars[n.Identifier] = g.scope.all[n.Identifier]
+		g.assign(n.Identifier, value)
+
+	case *ast.IndexAssignStatNode:
+		// The array is read for its length, which fails when it is unset.
+		array, err := g.expression(&ast.VariableReferenceNode{Pos: n.Pos, Name: n.Identifier})
+		if err != nil {
+			return err
+		}
+		index, err := g.expression(n.Index)
+		if err != nil {
+			return err
+		}
+		value, err := g.expression(n.Value)
+		if err != nil {
+			return err
+		}
+		v := g.lookup(n.Identifier)
+		g.printf("%s[index(len(%s), %s, %q)] = %s\n", v.goName, array, index, n.Index.Position().Location(), value)
+
+	case *ast.PrintStatNode:
+		values, err := g.printArgs(n.Values)
+		if err != nil {
+			return err
+		}
+		if n.Newline {
+			g.printf("fmt.Println(%s)\n", values)
+		} else {
+			g.printf("write(%s)\n", values)
+		}
+	case *ast.PrintfNode:
+		args, err := g.printArgs(n.Args)
+		if err != nil {
+			return err
+		}
+		if args != "" {
+			args = ", " + args
+		}
+		g.printf("fmt.Printf(%q%s)\n", n.Format, args)
+
+	case *ast.IfStatNode:
+		condition, err := g.expression(n.Condition)
+		if err != nil {
+			return err
+		}
+		g.printf("if %s {\n", condition)
+		if err := g.statement(n.ThenBranch); err != nil {
+			return err
+		}
+		if n.ElseBranch != nil {
+			g.printf("} else {\n")
+			if err := g.statement(n.ElseBranch); err != nil {
+				return err
+			}
+		}
+		g.printf("}\n")
+
+	case *ast.ForStatNode:
+		// The counter is hidden, so the body cannot change the number of
+		// iterations, like in the interpreter.
+		initial, err := g.expression(n.Initial)
+		if err != nil {
+			return err
+		}
+		final, err := g.expression(n.Final)
+		if err != nil {
+			return err
+		}
+		// The counter goes one past the final value, which may be the
+		// largest integer.
+		// The trace is cut back to the loop at the start of every iteration,
+		// in case break or continue left a block early.
+		g.printf("{\n")
+		g.printf("t := push(%q, %q, true)\n", "for loop, "+n.Identifier+" = ", n.Pos.Location())
+		g.printf("for n, last := int64(%s), int64(%s); n <= last; n++ {\n", initial, final)
+		g.printf("trace, trace[t].n = trace[:t+1], int(n)\n")
+		g.assign(n.Identifier, "int(n)")
+		if err := g.statement(n.Body); err != nil {
+			return err
+		}
+		g.printf("}\n")
+		g.printf("trace = trace[:t]\n")
+		g.printf("}\n")
+
+	case *ast.WhileStatNode:
+		condition, err := g.expression(n.Condition)
+		if err != nil {
+			return err
+		}
+		g.printf("{\n")
+		g.printf("t := push(\"while loop\", %q, false)\n", n.Pos.Location())
+		g.printf("for ; %s; trace = trace[:t+1] {\n", condition)
+		if err := g.statement(n.Body); err != nil {
+			return err
+		}
+		g.printf("}\n")
+		g.printf("trace = trace[:t]\n")
+		g.printf("}\n")
+
+	case *ast.RepeatStatNode:
+		// The condition sees the variables assigned in the body, so it is
+		// translated after it. continue skips the rest of the body, not the
+		// condition.
+		out := g.out
+		g.out = &bytes.Buffer{}
+		if err := g.statement(n.Body); err != nil {
+			return err
+		}
+		body := g.out
+		g.out = out
+		condition, err := g.expression(n.Condition)
+		if err != nil {
+			return err
+		}
+		g.printf("{\n")
+		g.printf("t := push(\"repeat loop\", %q, false)\n", n.Pos.Location())
+		g.printf("for again := true; again; again = resume(t) && !%s {\n", condition)
+		g.out.Write(body.Bytes())
+		g.printf("}\n")
+		g.printf("trace = trace[:t]\n")
+		g.printf("}\n")
+
+	case *ast.BreakNode:
+		g.printf("break\n")
+	case *ast.ContinueNode:
+		g.printf("continue\n")
+	case *ast.ExitNode:
+		if n.Status == nil {
+			g.printf("os.Exit(0)\n")
+			break
+		}
+		status, err := g.expression(n.Status)
+		if err != nil {
+			return err
+		}
+		g.printf("exit(%s, %q)\n", status, n.Pos.Location())
+
+	case *ast.PragmaNode:
+		// the Checker already enforced it
+
+	case *ast.FunctionDeclNode:
+		if !emitted(n) {
+			break // never called, so it has no signature
+		}
+		params := &scope{
+			vars:     make(map[string]*variable),
+			all:      make(map[string]*variable),
+			function: true,
+		}
+		for idx, param := range n.Params {
+			v := &variable{name: param, goName: "v_" + param, typ: n.ParamTypes[idx], read: true, param: true}
+			params.vars[param], params.all[param] = v, v
+			params.order = append(params.order, v)
+		}
+		g.printf("if f_%s != nil {\nfail(%q, %q)\n}\n", n.Name, "function "+n.Name+" is already declared", n.Pos.Location())
+		g.printf("f_%s = %s {\n", n.Name, signature(n))
+		g.printf("defer enter(%q, at)()\n", n.Name)
+		g.inFunction = true
+		err := g.block(statementsOf(n.Body), params)
+		g.inFunction = false
+		if err != nil {
+			return err
+		}
+		if statements := statementsOf(n.Body); !n.Procedure && (len(statements) == 0 || !terminates(statements[len(statements)-1])) {
+			g.printf("panic(missingReturn(%q, at))\n", n.Name)
+		}
+		g.printf("}\n")
+
+	case *ast.CallNode:
+		call, err := g.call(n)
+		if err != nil {
+			return err
+		}
+		g.printf("%s\n", call)
+	case *ast.BuiltinNode:
+		call, err := g.library(n)
+		if err != nil {
+			return err
+		}
+		g.printf("_ = %s\n", call)
+
+	case *ast.ReturnNode:
+		if n.Value == nil {
+			g.printf("return\n")
+			break
+		}
+		value, err := g.value(n.Value)
+		if err != nil {
+			return err
+		}
+		g.printf("return %s\n", value)
+
+	default:
+		return fmt.Errorf("emit: unsupported statement %T", node)
+	}
+	return nil
+}
+
+// printArgs translates the values print and printf show, arrays are
+// formatted the way print shows them.
+func (g *generator) printArgs(nodes []ast.Node) (string, error) {
+	args := make([]string, len(nodes))
+	for idx, node := range nodes {
+		value, err := g.expression(node)
+		if err != nil {
+			return "", err
+		}
+		switch typeOf(node, g.functions) {
+		case ast.INT_ARRAY_TYPE:
+			value = fmt.Sprintf("formatInts(%s)", value)
+		case ast.STRING_ARRAY_TYPE:
+			value = fmt.Sprintf("formatStrs(%s)", value)
+		}
+		args[idx] = value
+	}
+	return strings.Join(args, ", "), nil
+}
+
+// assign writes to the variable of the blocks the assignment is in, or else
+// of the innermost scope, like interfaces.VariablesTable.SetValue.
+func (g *generator) assign(name, value string) {
+	v := binding(g.scope, name)
+	if v == nil {
+		v = g.scope.all[name]
+		g.scope.vars[name] = v
+	}
+	g.printf("%s = %s\n", v.goName, value)
+	if v.set != "" {
+		g.printf("%s = true\n", v.set)
+	}
+}
+
+func (g *generator) call(n *ast.CallNode) (string, error) {
+	if _, ok := g.functions[n.Name]; !ok {
+		return "", fmt.Errorf("emit: undefined function: %s", n.Name)
+	}
+	g.called[n.Name] = true
+	call := fmt.Sprintf("get_%s(%q)(%q", n.Name, n.Pos.Location(), n.Pos.Location())
+	for _, arg := range n.Args {
+		value, err := g.value(arg)
+		if err != nil {
+			return "", err
+		}
+		call += ", " + value
+	}
+	return call + ")", nil
+}
+
+// failing are the library functions which can stop the program, their
+// runtime functions take the location of the call before the arguments.
+var failing = map[string]bool{
+	"repeat": true,
+	"abs":    true,
+	"pow":    true,
+	"isqrt":  true,
+	"gcd":    true,
+}
+
+// library translates a call of a library function into a call of the runtime
+// function of the same name.
+func (g *generator) library(n *ast.BuiltinNode) (string, error) {
+	if _, ok := ast.Builtins[n.Name]; !ok {
+		return "", fmt.Errorf("emit: undefined function: %s", n.Name)
+	}
+	args := n.Args
+	if failing[n.Name] {
+		args = append([]ast.Node{&ast.StringLiteral{Value: n.Pos.Location()}}, args...)
+	}
+	return g.builtin(n.Name, args...)
+}
+
+// value translates an expression whose value is stored, in a variable or a
+// parameter. Arrays are copied from variables, because Go slices share
+// their elements.
+func (g *generator) value(node ast.Node) (string, error) {
+	value, err := g.expression(node)
+	if err != nil {
+		return "", err
+	}
+	if ref, ok := node.(*ast.VariableReferenceNode); ok && ref.Type.Elem() != ast.INVALID_TYPE {
+		value = fmt.Sprintf("append(%s(nil), %s...)", goType(ref.Type), value)
+	}
+	return value, nil
+}
+
+// terminates tells whether the statement always returns, so that Go needs
+// nothing after it.
+func terminates(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.ReturnNode:
+		return true
+	case *ast.IfStatNode:
+		return n.ElseBranch != nil && terminates(n.ThenBranch) && terminates(n.ElseBranch)
+	case *ast.BlockNode:
+		return len(n.Statements) > 0 && terminates(n.Statements[len(n.Statements)-1])
+	}
+	return false
+}
+
+// operators maps the operators of the language to the ones of Go.
+var operators = map[string]string{
+	"+":   "+",
+	"-":   "-",
+	"*":   "*",
+	"/":   "/",
+	"%":   "%",
+	"=":   "==",
+	"<>":  "!=",
+	"<":   "<",
+	"<=":  "<=",
+	">":   ">",
+	">=":  ">=",
+	"==":  "==",
+	"!=":  "!=",
+	"and": "&&",
+	"or":  "||",
+	"xor": "!=",
+}
+
+func (g *generator) binary(op string, left, right ast.Node) (string, error) {
+	goOp, ok := operators[op]
+	if !ok {
+		return "", fmt.Errorf("emit: operation not supported: %s", op)
+	}
+	l, err := g.expression(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := g.expression(right)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s %s %s)", l, goOp, r), nil
+}
+
+// builtin translates a call of a function of the runtime.
+func (g *generator) builtin(name string, args ...ast.Node) (string, error) {
+	call := name + "("
+	for idx, arg := range args {
+		value, err := g.expression(arg)
+		if err != nil {
+			return "", err
+		}
+		if idx > 0 {
+			call += ", "
+		}
+		call += value
+	}
+	return call + ")", nil
+}
+
+// conversion calls the runtime function of a conversion built-in with its
+// radix, which is 10 when the program doesn't give one, and its location.
+func (g *generator) conversion(name string, pos ast.Pos, value, radix ast.Node) (string, error) {
+	if radix == nil {
+		radix = &ast.NumLiteralNode{Value: 10}
+	}
+	return g.builtin(name, value, radix, &ast.StringLiteral{Value: pos.Location()})
+}
+
+func (g *generator) expression(node ast.Node) (string, error) {
+	switch n := node.(type) {
+	case *ast.NumLiteralNode:
+		return strconv.Itoa(n.Value), nil
+	case *ast.StringLiteral:
+		return strconv.Quote(n.Value), nil
+	case *ast.BoolLiteral:
+		return strconv.FormatBool(n.Value), nil
+
+	case *ast.VariableReferenceNode:
+		v := g.lookup(n.Name)
+		if v == nil {
+			return "", fmt.Errorf("emit: undefined variable: %s", n.Name)
+		}
+		v.read = true
+		if v.set == "" {
+			return v.goName, nil
+		}
+		get := "getInt"
+		switch v.typ {
+		case ast.STRING_TYPE:
+			get = "getStr"
+		case ast.BOOL_TYPE:
+			get = "getBool"
+		case ast.INT_ARRAY_TYPE:
+			get = "getInts"
+		case ast.STRING_ARRAY_TYPE:
+			get = "getStrs"
+		}
+		return fmt.Sprintf("%s(%s, %s, %q, %q)", get, v.goName, v.set, n.Name, n.Pos.Location()), nil
+
+	case *ast.NumExprNode:
+		l, err := g.expression(n.Left)
+		if err != nil {
+			return "", err
+		}
+		r, err := g.expression(n.Right)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("arith('%s', %s, %s, %q)", n.Op, l, r, n.Pos.Location()), nil
+	case *ast.BoolExprNode:
+		return g.binary(n.Op, n.Left, n.Right)
+	case *ast.NumComparisonExprNode:
+		return g.binary(n.Op, n.Left, n.Right)
+	case *ast.StrComparisonExprNode:
+		return g.binary(n.Op, n.Left, n.Right)
+	case *ast.LogicalExprNode:
+		return g.binary(n.Op, n.Left, n.Right)
+	case *ast.Concatenate:
+		return g.binary("+", n.Left, n.Right)
+
+	case *ast.UnaryOpNode:
+		operand, err := g.expression(n.Operand)
+		if err != nil {
+			return "", err
+		}
+		if n.Op == "-" {
+			return fmt.Sprintf("negate(%s, %q)", operand, n.Pos.Location()), nil
+		}
+		return fmt.Sprintf("(%s%s)", n.Op, operand), nil
+
+	case *ast.ReadIntNode:
+		return fmt.Sprintf("readInt(%q)", n.Pos.Location()), nil
+	case *ast.ReadStr:
+		return fmt.Sprintf("readStr(%q)", n.Pos.Location()), nil
+	case *ast.ReadLn:
+		return fmt.Sprintf("readLn(%q)", n.Pos.Location()), nil
+	case *ast.EOFNode:
+		return "eof()", nil
+	case *ast.LengthNode:
+		if typeOf(n.Str, g.functions).Elem() != ast.INVALID_TYPE {
+			return g.builtin("len", n.Str)
+		}
+		return g.builtin("length", n.Str)
+
+	case *ast.ArrayLiteral:
+		elems := ""
+		for idx, elem := range n.Elems {
+			value, err := g.expression(elem)
+			if err != nil {
+				return "", err
+			}
+			if idx > 0 {
+				elems += ", "
+			}
+			elems += value
+		}
+		return fmt.Sprintf("%s{%s}", goType(n.Type), elems), nil
+	case *ast.IndexNode:
+		array, err := g.expression(n.Array)
+		if err != nil {
+			return "", err
+		}
+		index, err := g.expression(n.Index)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s[index(len(%s), %s, %q)]", array, array, index, n.Index.Position().Location()), nil
+	case *ast.PositionNode:
+		return g.builtin("position", n.Str, n.Substr)
+	case *ast.Substring:
+		return g.builtin("substring", n.Str, n.Start, n.Length)
+	case *ast.StrNode:
+		return g.conversion("formatInt", n.Pos, n.Value, n.Radix)
+	case *ast.IntNode:
+		return g.conversion("parseInt", n.Pos, n.Str, n.Radix)
+	case *ast.IsIntNode:
+		return g.conversion("isInt", n.Pos, n.Str, n.Radix)
+	case *ast.ChrNode:
+		return g.builtin("chr", n.Code, &ast.StringLiteral{Value: n.Pos.Location()})
+	case *ast.OrdNode:
+		return g.builtin("ord", n.Str, &ast.StringLiteral{Value: n.Pos.Location()})
+
+	case *ast.CallNode:
+		return g.call(n)
+	case *ast.BuiltinNode:
+		return g.library(n)
+	}
+	return "", fmt.Errorf("emit: unsupported expression %T", node)
+}
+
+// typeOf returns the type of an expression, from the types the Checker
+// resolved for variables and functions.
+func typeOf(node ast.Node, functions map[string]*ast.FunctionDeclNode) ast.Type {
+	switch n := node.(type) {
+	case *ast.NumLiteralNode, *ast.NumExprNode, *ast.ReadIntNode, *ast.LengthNode, *ast.PositionNode, *ast.IntNode, *ast.OrdNode:
+		return ast.INT_TYPE
+	case *ast.StringLiteral, *ast.ReadStr, *ast.ReadLn, *ast.Concatenate, *ast.Substring, *ast.StrNode, *ast.ChrNode:
+		return ast.STRING_TYPE
+	case *ast.BoolLiteral, *ast.EOFNode, *ast.IsIntNode, *ast.BoolExprNode, *ast.NumComparisonExprNode, *ast.StrComparisonExprNode, *ast.LogicalExprNode:
+		return ast.BOOL_TYPE
+	case *ast.UnaryOpNode:
+		if n.Op == "!" {
+			return ast.BOOL_TYPE
+		}
+		return ast.INT_TYPE
+	case *ast.VariableReferenceNode:
+		return n.Type
+	case *ast.ArrayLiteral:
+		return n.Type
+	case *ast.IndexNode:
+		return n.Type
+	case *ast.CallNode:
+		if decl, ok := functions[n.Name]; ok {
+			return decl.Result
+		}
+	case *ast.BuiltinNode:
+		if b, ok := ast.Builtins[n.Name]; ok {
+			return b.Result
+		}
+	}
+	return ast.INVALID_TYPE
+}
@@ -0,0 +1,536 @@
+package emit
+
+// runtime is appended to every generated program. It holds the entry point,
+// which reports runtime errors the way the interpreter does, and the
+// built-ins of the language. arith, negate and readInt must behave like
+// ast.Arithmetic, ast.Negate and ast.ParseInt, readWord, readLn and eof like
+// the methods of ast.Input, and length, position and substring like
+// ast.LengthOf, ast.PositionOf and ast.SubstringOf, and formatInt, parseInt,
+// isInt, chr and ord like ast.FormatInt, ast.ParseIntRadix, ast.IsInt, ast.Chr
+// and ast.Ord. The library functions are named like in ast.Builtins and
+// behave the same. The generator declares
+// wrapOverflow after it.
+const runtime = `
+// maxCallDepth matches the recursion limit of the AUG interpreter.
+const maxCallDepth = 10000
+
+// reportedFrames is how many frames of each end of the trace an error shows.
+const reportedFrames = 10
+
+var (
+	stdin     = bufio.NewReader(os.Stdin)
+	callDepth int
+	trace     []frame // the constructs being run, the innermost last
+)
+
+// frame is a loop, block or call which runtime errors are traced to, like
+// ast.Frame.
+type frame struct {
+	what    string
+	at      string
+	counter bool // n is the counter of a for loop, shown after what
+	n       int
+}
+
+// push adds a construct to the trace, and returns the length to cut the
+// trace back to once it is done.
+func push(what, at string, counter bool) int {
+	trace = append(trace, frame{what: what, at: at, counter: counter})
+	return len(trace) - 1
+}
+
+// resume cuts the trace back to the loop at t, it is true so that it can
+// precede a condition.
+func resume(t int) bool {
+	trace = trace[:t+1]
+	return true
+}
+
+// augError is a runtime error of the AUG program.
+type augError string
+
+func main() {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(augError)
+			if !ok {
+				panic(r)
+			}
+			fmt.Println(string(e))
+			os.Exit(1)
+		}
+	}()
+	run()
+}
+
+// fail stops the program with an error at the source location at.
+func fail(msg, at string) {
+	panic(failure(msg, at))
+}
+
+// failure is the error at the source location at, followed by the trace the
+// way ast.RuntimeError.Report shows it.
+func failure(msg, at string) augError {
+	msg += " @" + at
+	for idx := len(trace) - 1; idx >= 0; idx-- {
+		if idx == len(trace)-reportedFrames-1 && len(trace) > 2*reportedFrames {
+			msg += fmt.Sprintf("\n\t... %d more", len(trace)-2*reportedFrames)
+		}
+		if idx >= reportedFrames && idx < len(trace)-reportedFrames {
+			continue
+		}
+		f := trace[idx]
+		msg += "\n\tin " + f.what
+		if f.counter {
+			msg += strconv.Itoa(f.n)
+		}
+		msg += " @" + f.at
+	}
+	return augError(msg)
+}
+
+// enter counts the nested function calls and traces them, the returned
+// function leaves the call again.
+func enter(name, at string) func() {
+	if callDepth >= maxCallDepth {
+		fail("call stack exhausted calling "+name, at)
+	}
+	callDepth++
+	t := push("call of "+name, at, false)
+	return func() {
+		callDepth--
+		trace = trace[:t]
+	}
+}
+
+// missingReturn is the error at the call of a function which ended without
+// returning a value, the call itself is no longer traced.
+func missingReturn(name, at string) augError {
+	trace = trace[:len(trace)-1]
+	return failure("function "+name+" ended without returning a value", at)
+}
+
+// exit stops the program with the status, which must be one the operating
+// system can report, like ast.CheckExitStatus.
+func exit(status int, at string) {
+	if status < 0 || status > 255 {
+		fail(fmt.Sprintf("exit status %d out of range 0 to 255", status), at)
+	}
+	os.Exit(status)
+}
+
+// getInt and getStr read variables which might not be assigned yet.
+func getInt(value int, set bool, name, at string) int {
+	if !set {
+		fail("undefined variable: "+name, at)
+	}
+	return value
+}
+
+func getStr(value string, set bool, name, at string) string {
+	if !set {
+		fail("undefined variable: "+name, at)
+	}
+	return value
+}
+
+func getBool(value bool, set bool, name, at string) bool {
+	if !set {
+		fail("undefined variable: "+name, at)
+	}
+	return value
+}
+
+func getInts(value []int, set bool, name, at string) []int {
+	if !set {
+		fail("undefined variable: "+name, at)
+	}
+	return value
+}
+
+func getStrs(value []string, set bool, name, at string) []string {
+	if !set {
+		fail("undefined variable: "+name, at)
+	}
+	return value
+}
+
+// index checks the one-indexed position of an element of an array of the
+// given size, and returns it zero-indexed.
+func index(size, i int, at string) int {
+	if i < 1 || i > size {
+		fail(fmt.Sprintf("index %d out of bounds for array of size %d", i, size), at)
+	}
+	return i - 1
+}
+
+// arith applies an integer operator with 32-bit results, which either wrap
+// around or stop the program. Dividing by zero always stops it.
+func arith(op byte, a, b int, at string) int {
+	if (op == '/' || op == '%') && b == 0 {
+		fail(fmt.Sprintf("division by zero: %d %c 0", a, op), at)
+	}
+	var value int64
+	switch op {
+	case '+':
+		value = int64(a) + int64(b)
+	case '-':
+		value = int64(a) - int64(b)
+	case '*':
+		value = int64(a) * int64(b)
+	case '/':
+		value = int64(a) / int64(b)
+	case '%':
+		value = int64(a) % int64(b)
+	}
+	if value < math.MinInt32 || value > math.MaxInt32 {
+		if !wrapOverflow {
+			fail(fmt.Sprintf("integer overflow: %d %c %d", a, op, b), at)
+		}
+		value = int64(int32(value))
+	}
+	return int(value)
+}
+
+func negate(a int, at string) int {
+	if a == math.MinInt32 {
+		if !wrapOverflow {
+			fail(fmt.Sprintf("integer overflow: -(%d)", a), at)
+		}
+		return a
+	}
+	return -a
+}
+
+// readWord reads the next word of input, and the rest of its line when that
+// is only spaces.
+func readWord(name, at string) string {
+	r, _, err := stdin.ReadRune()
+	for err == nil && unicode.IsSpace(r) {
+		r, _, err = stdin.ReadRune()
+	}
+	if err != nil {
+		fail(name+": "+err.Error(), at)
+	}
+	var b strings.Builder
+	for err == nil && !unicode.IsSpace(r) {
+		b.WriteRune(r)
+		r, _, err = stdin.ReadRune()
+	}
+	for err == nil && r != '\n' {
+		if !unicode.IsSpace(r) {
+			stdin.UnreadRune()
+			break
+		}
+		r, _, err = stdin.ReadRune()
+	}
+	return b.String()
+}
+
+// readLn reads the rest of the line without the line break.
+func readLn(at string) string {
+	line, err := stdin.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fail("readln: "+err.Error(), at)
+	}
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r")
+}
+
+// eof tells whether only spaces and line breaks are left, like ast.Input.EOF.
+func eof() bool {
+	off := 0
+	for {
+		if off+utf8.UTFMax > stdin.Size() {
+			stdin.Discard(off)
+			off = 0
+		}
+		buf, _ := stdin.Peek(off + 1)
+		for len(buf) > off && !utf8.FullRune(buf[off:]) {
+			more, err := stdin.Peek(len(buf) + 1)
+			if err != nil {
+				break
+			}
+			buf = more
+		}
+		if off >= len(buf) {
+			return true
+		}
+		r, size := utf8.DecodeRune(buf[off:])
+		if !unicode.IsSpace(r) {
+			return false
+		}
+		off += size
+	}
+}
+
+// write prints the values the way fmt.Println does, without the line break.
+func write(values ...interface{}) {
+	line := fmt.Sprintln(values...)
+	fmt.Print(line[:len(line)-1])
+}
+
+func readInt(at string) int {
+	input := readWord("readint", at)
+	value, err := strconv.ParseInt(input, 10, 32)
+	if errors.Is(err, strconv.ErrRange) {
+		fail("integer out of range: "+input, at)
+	}
+	if err != nil {
+		fail("expected integer, but got: "+input, at)
+	}
+	return int(value)
+}
+
+func readStr(at string) string {
+	return readWord("readstr", at)
+}
+
+// formatInts and formatStrs show arrays the way ast.FormatArray does.
+func formatInts(a []int) string {
+	elems := make([]string, len(a))
+	for idx, elem := range a {
+		elems[idx] = strconv.Itoa(elem)
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+func formatStrs(a []string) string {
+	elems := make([]string, len(a))
+	for idx, elem := range a {
+		elems[idx] = strconv.Quote(elem)
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+func length(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+func position(s, sub string) int {
+	idx := strings.Index(s, sub)
+	if idx < 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(s[:idx]) + 1
+}
+
+func substring(s string, pos, length int) string {
+	runes := []rune(s)
+	if pos < 1 || pos > len(runes) || length <= 0 {
+		return ""
+	}
+	end := pos + length - 1
+	if end > len(runes) {
+		end = len(runes)
+	}
+	return string(runes[pos-1 : end])
+}
+
+// checkRadix fails unless the radix of a conversion is 2 to 36.
+func checkRadix(name string, radix int, at string) {
+	if radix < 2 || radix > 36 {
+		fail(fmt.Sprintf("%s: radix %d is not 2 to 36", name, radix), at)
+	}
+}
+
+func formatInt(value, radix int, at string) string {
+	checkRadix("str", radix, at)
+	return strconv.FormatInt(int64(value), radix)
+}
+
+func parseInt(s string, radix int, at string) int {
+	checkRadix("int", radix, at)
+	value, err := strconv.ParseInt(s, radix, 32)
+	if errors.Is(err, strconv.ErrRange) {
+		fail(fmt.Sprintf("int: %q is out of range", s), at)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("int: %q is not an integer", s)
+		if radix != 10 {
+			msg += fmt.Sprintf(" in base %d", radix)
+		}
+		fail(msg, at)
+	}
+	return int(value)
+}
+
+func isInt(s string, radix int, at string) bool {
+	checkRadix("isint", radix, at)
+	_, err := strconv.ParseInt(s, radix, 32)
+	return err == nil
+}
+
+func chr(code int, at string) string {
+	if code < 0 || code > utf8.MaxRune || (code >= 0xD800 && code <= 0xDFFF) {
+		fail(fmt.Sprintf("chr: %d is not a code point", code), at)
+	}
+	return string(rune(code))
+}
+
+func ord(s, at string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) {
+		fail(fmt.Sprintf("ord: %q is not one character", s), at)
+	}
+	return int(r)
+}
+
+func upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+func lower(s string) string {
+	return strings.ToLower(s)
+}
+
+func trim(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for left, right := 0, len(runes)-1; left < right; left, right = left+1, right-1 {
+		runes[left], runes[right] = runes[right], runes[left]
+	}
+	return string(runes)
+}
+
+func replace(s, old, with string) string {
+	return strings.ReplaceAll(s, old, with)
+}
+
+func split(s, sep string) []string {
+	return strings.Split(s, sep)
+}
+
+func join(a []string, sep string) string {
+	return strings.Join(a, sep)
+}
+
+func repeat(at, s string, count int) string {
+	if count <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) > math.MaxInt32/count {
+		fail(fmt.Sprintf("repeat: the result would be longer than %d characters", math.MaxInt32), at)
+	}
+	return strings.Repeat(s, count)
+}
+
+func startswith(s, prefix string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+func endswith(s, suffix string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+func rposition(s, sub string) int {
+	idx := strings.LastIndex(s, sub)
+	if idx < 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(s[:idx]) + 1
+}
+
+func abs(at string, value int) int {
+	if value < 0 {
+		if value == math.MinInt32 && !wrapOverflow {
+			fail(fmt.Sprintf("integer overflow: abs(%d)", value), at)
+		}
+		return int(-int32(value))
+	}
+	return value
+}
+
+func min(values ...int) int {
+	least := values[0]
+	for _, value := range values[1:] {
+		if value < least {
+			least = value
+		}
+	}
+	return least
+}
+
+func max(values ...int) int {
+	greatest := values[0]
+	for _, value := range values[1:] {
+		if value > greatest {
+			greatest = value
+		}
+	}
+	return greatest
+}
+
+func pow(at string, base, exp int) int {
+	if exp < 0 {
+		fail(fmt.Sprintf("pow: negative exponent %d", exp), at)
+	}
+	result, square, overflowed := int64(1), int64(base), false
+	wrapped := func(value int64) int64 {
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			overflowed = true
+		}
+		return int64(int32(value))
+	}
+	for e := exp; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = wrapped(result * square)
+		}
+		if e > 1 {
+			square = wrapped(square * square)
+		}
+	}
+	if overflowed && !wrapOverflow {
+		fail(fmt.Sprintf("integer overflow: pow(%d, %d)", base, exp), at)
+	}
+	return int(result)
+}
+
+func isqrt(at string, value int) int {
+	if value < 0 {
+		fail(fmt.Sprintf("isqrt: negative argument %d", value), at)
+	}
+	root := int(math.Sqrt(float64(value)))
+	for root*root > value {
+		root--
+	}
+	for (root+1)*(root+1) <= value {
+		root++
+	}
+	return root
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
+
+func gcd(at string, values ...int) int {
+	var divisor int64
+	for _, value := range values {
+		x, y := int64(value), divisor
+		if x < 0 {
+			x = -x
+		}
+		for y != 0 {
+			x, y = y, x%y
+		}
+		divisor = x
+	}
+	if divisor > math.MaxInt32 {
+		if !wrapOverflow {
+			fail(fmt.Sprintf("integer overflow: gcd(%d, %d)", values[0], values[1]), at)
+		}
+		return math.MinInt32
+	}
+	return int(divisor)
+}
+`
@@ -1,17 +1,37 @@
 package interfaces
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
 
 type ValueType int
 
 const (
 	STRING_VALUE ValueType = iota
 	INTEGER_VALUE
+	ARRAY_VALUE
+	BOOLEAN_VALUE
+)
+
+// The errors of SetValue for the assignments it refuses.
+var (
+	ErrConstant   = errors.New("cannot assign to constant")
+	ErrUndeclared = errors.New("assignment to undeclared variable")
 )
 
+// VariablesTable is a scope of variables. The scopes of blocks are nested
+// within the scope of the function or program they are part of.
 type VariablesTable struct {
 	Parent *VariablesTable
+	// Strict refuses assignments which would create a variable, only
+	// declarations do. The scopes made from this one inherit it.
+	Strict bool
+
 	vars   map[string]Value
+	consts map[string]bool // the variables declared as constants
+	block  bool            // assignments reach through to the parent
 }
 
 // GetValue returns the value of the variable with the given name.
@@ -33,16 +53,38 @@ func (vt *VariablesTable) GetValue(identifier string) (Value, bool) {
 	return Value{}, false
 }
 
-// SetValue sets the value of the variable with the given name.
-// If the variable already exists and the new value has a different type, it returns an error.
+// SetValue sets the value of the variable with the given name. The variable
+// is looked up through the scopes of blocks, and it is created in this scope
+// when it doesn't exist yet, unless the table is Strict.
+// If the variable already exists and the new value has a different type, or
+// it is a constant, it returns an error.
 func (vt *VariablesTable) SetValue(name string, newValue Value) error {
 	// Check if the variable already exists.
+	for scope := vt; scope != nil; scope = scope.Parent {
+		if _, found := scope.vars[name]; found {
+			vt = scope
+			break
+		}
+		if !scope.block {
+			break
+		}
+	}
 	oldValue, found := vt.vars[name]
+	switch {
+	case !found && vt.Strict:
+		return fmt.Errorf("%w %s", ErrUndeclared, name)
+	case vt.consts[name]:
+		return fmt.Errorf("%w %s", ErrConstant, name)
+	}
 	if found {
 		// Check if the new value has a different type.
 		if oldValue.Type != newValue.Type {
 			return fmt.Errorf("cannot change the type of variable %s", name)
 		}
+		// Arrays also keep the type of their elements.
+		if oldValue.Type == ARRAY_VALUE && oldValue.Elem != newValue.Elem {
+			return fmt.Errorf("cannot change the element type of array %s", name)
+		}
 	}
 
 	// Set the variable's value.
@@ -50,17 +92,70 @@ func (vt *VariablesTable) SetValue(name string, newValue Value) error {
 	return nil
 }
 
+// Declare creates the variable in this scope, hiding any variable of the
+// same name of the upper scopes.
+func (vt *VariablesTable) Declare(name string, value Value) {
+	vt.vars[name] = value
+	delete(vt.consts, name)
+}
+
+// DeclareConstant creates a variable like Declare, which SetValue then
+// refuses to change.
+func (vt *VariablesTable) DeclareConstant(name string, value Value) {
+	vt.vars[name] = value
+	if vt.consts == nil {
+		vt.consts = make(map[string]bool)
+	}
+	vt.consts[name] = true
+}
+
+// IsConstant tells whether the variable with the given name, as GetValue
+// finds it, is a constant.
+func (vt *VariablesTable) IsConstant(name string) bool {
+	for scope := vt; scope != nil; scope = scope.Parent {
+		if _, found := scope.vars[name]; found {
+			return scope.consts[name]
+		}
+	}
+	return false
+}
+
+// Names returns the names of the variables of this scope, without the ones of
+// the upper scopes, in alphabetical order.
+func (vt *VariablesTable) Names() []string {
+	names := make([]string, 0, len(vt.vars))
+	for name := range vt.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func MakeVariablesTable() VariablesTable {
 	return VariablesTable{vars: make(map[string]Value)}
 }
 
-func MakeChildVariablesTable(parent VariablesTable) VariablesTable {
-	return VariablesTable{Parent: &parent, vars: make(map[string]Value)}
-	return VariablesTable{vars: make(map[string]Value)}
+// MakeChildVariablesTable makes the scope of a function called from the
+// parent, the function reads the variables of the parent but its assignments
+// create variables of its own.
+func MakeChildVariablesTable(parent *VariablesTable) VariablesTable {
+	return VariablesTable{Parent: parent, Strict: parent.Strict, vars: make(map[string]Value)}
+}
+
+// MakeBlockVariablesTable makes the scope of a block within the parent, whose
+// variables it assigns to.
+func MakeBlockVariablesTable(parent *VariablesTable) VariablesTable {
+	return VariablesTable{Parent: parent, Strict: parent.Strict, vars: make(map[string]Value), block: true}
 }
 
 type Value struct {
 	Type ValueType
 	Str  string
 	Int  int
+	Bool bool
+
+	// Elems are the elements of an ARRAY_VALUE, which are all of the type
+	// Elem.
+	Elem  ValueType
+	Elems []Value
 }
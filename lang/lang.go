@@ -0,0 +1,298 @@
+// Package lang reads and runs AUG programs, it is what the compiler command
+// is built on. Parse turns the source into an AST, which Run type checks and
+// then runs with the given input and output:
+//
+//	program, errs := lang.Parse(strings.NewReader(`print("hello");`))
+//	if len(errs) > 0 {
+//		// syntax errors, every one of them is a *LexParseErr
+//	}
+//	err := lang.Run(ctx, program, lang.Options{Stdout: &out})
+package lang
+
+import (
+	"aug/ast"
+	"aug/interfaces"
+	"aug/vm"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Error string
+
+// Error fulfills the error interface of this type.
+func (e Error) Error() string { return string(e) }
+
+// LexParseErr is a permanent failure error to notify about borkage.
+type LexParseErr struct {
+	Err Error
+	Str string
+	Row int // this is zero-indexed (the first line is 0)
+	Col int // this is zero-indexed (the first char is 0)
+
+	// Filename is the file that this error occurred in. If this is unknown,
+	// then it will be empty. This is not set when run by the basic Parse
+	// function.
+	Filename string
+
+	// Lexer is set for text the lexer didn't recognize, the other errors
+	// are syntax errors found by the parser.
+	Lexer bool
+	// EOF is set when the source ended before the parser expected it to, so
+	// more input might complete it.
+	EOF bool
+}
+
+// Error displays this error with all the relevant state information.
+func (e *LexParseErr) Error() string {
+	location := fmt.Sprintf("%d:%d", e.Row+1, e.Col+1)
+	if e.Filename != "" {
+		location = e.Filename + ":" + location
+	}
+	if e.Str == "" {
+		return fmt.Sprintf("%s @%s", e.Err, location)
+	}
+	return fmt.Sprintf("%s: `%s` @%s", e.Err, e.Str, location)
+}
+
+// lexToken is the last token read by the lexer, syntax errors are reported
+// at it.
+type lexToken struct {
+	str     string
+	row     int
+	col     int
+	invalid bool      // the lexer didn't recognize the token
+	trivia  []Comment // the comments before the token
+}
+
+type lexParseAST struct {
+	ast ast.Node
+	row int
+	col int
+
+	filename string // source file, empty when reading stdin
+	offset   int    // byte offset of the lexer in the source
+	eof      bool   // the lexer reached the end of the source
+
+	token     lexToken
+	trivia    []Comment      // the comments since the last token
+	lexerErrs []*LexParseErr // from lexer
+	parseErrs []*LexParseErr // from Error(e string)
+}
+
+// startLexer chooses what the parser reads. When start is set it is sent
+// before the tokens of the source, START_EXPR makes the parser read a single
+// expression instead of statements.
+type startLexer struct {
+	*Lexer
+	start int
+}
+
+func (l *startLexer) Lex(lval *yySymType) int {
+	if l.start != 0 {
+		token := l.start
+		l.start = 0
+		return token
+	}
+	token := l.Lexer.Lex(lval)
+	if token == 0 {
+		l.cast().eof = true
+	}
+	return token
+}
+
+// Parse reads a program. The errors are the syntax errors of the parser
+// followed by the text the lexer didn't recognize, as *LexParseErr.
+func Parse(src io.Reader) (ast.Node, []error) {
+	return ParseFile(src, "")
+}
+
+// ParseFile is Parse for a source file, whose name is part of the positions
+// in the AST and in the errors.
+func ParseFile(src io.Reader, filename string) (ast.Node, []error) {
+	return parse(src, filename, 0)
+}
+
+// ParseExpr reads a single expression instead of statements.
+func ParseExpr(src io.Reader) (ast.Node, []error) {
+	return parse(src, "", START_EXPR)
+}
+
+// Comment is a comment of the source, including its delimiters. The parser
+// skips comments, the lexer keeps them as the trivia of the next token.
+type Comment struct {
+	Text string
+	Pos  ast.Pos
+}
+
+// Token is a token of the source and the comments before it, which a
+// formatter needs to keep them where they were.
+type Token struct {
+	Kind   string // the name of the token in the grammar, like IDENT
+	Text   string
+	Pos    ast.Pos
+	Trivia []Comment
+}
+
+// Tokens reads the tokens of the source, up to the final EOF token, whose
+// trivia are the comments at the end. The errors are the text the lexer
+// didn't recognize.
+func Tokens(src io.Reader, filename string) ([]Token, []error) {
+	lp := &lexParseAST{filename: filename}
+	lexer := NewLexerWithInit(src, func(y *Lexer) { y.parseResult = lp })
+
+	var tokens []Token
+	for {
+		var lval yySymType
+		char, token := yylex1(lexer, &lval)
+		if char <= 0 {
+			tokens = append(tokens, Token{Kind: "EOF", Pos: ast.Pos{File: filename, End: lp.offset}, Trivia: lp.trivia})
+			break
+		}
+		tokens = append(tokens, Token{
+			Kind:   yyTokname(token),
+			Text:   lp.token.str,
+			Pos:    ast.Pos{File: filename, Line: lval.row + 1, Col: lval.col + 1, End: lval.end},
+			Trivia: lp.token.trivia,
+		})
+	}
+
+	var errs []error
+	for _, e := range lp.lexerErrs {
+		errs = append(errs, e)
+	}
+	return tokens, errs
+}
+
+func parse(src io.Reader, filename string, start int) (ast.Node, []error) {
+	lp := &lexParseAST{filename: filename}
+	lexer := &startLexer{
+		Lexer: NewLexerWithInit(src, func(y *Lexer) { y.parseResult = lp }),
+		start: start,
+	}
+	yyParse(lexer) // writes the result to lp.ast
+
+	var errs []error
+	for _, e := range lp.parseErrs {
+		errs = append(errs, e)
+	}
+	for _, e := range lp.lexerErrs {
+		errs = append(errs, e)
+	}
+	return lp.ast, errs
+}
+
+// Incomplete tells whether the source was only rejected because it ended
+// too early, as a statement typed over several lines does.
+func Incomplete(errs []error) bool {
+	if len(errs) != 1 {
+		return false
+	}
+	e, ok := errs[0].(*LexParseErr)
+	return ok && e.EOF && !e.Lexer
+}
+
+// Options are the engine a program runs on and the streams it uses. Missing
+// streams read nothing and discard what is written to them.
+type Options struct {
+	// Engine is either "tree" for the AST interpreter, which is the
+	// default, or "vm" to compile to bytecode for the virtual machine.
+	Engine string
+
+	// Overflow is what arithmetic does with results beyond 32 bits, it
+	// traps by default.
+	Overflow ast.Overflow
+	// Strict requires every variable to be declared, as the strict pragma
+	// does.
+	Strict bool
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	// Stderr receives the runtime error which stopped the program, the
+	// same one Run returns, together with its trace.
+	Stderr io.Writer
+}
+
+// TypeErrors are the errors the type checker found in a program, which is
+// then not run.
+type TypeErrors []error
+
+func (e TypeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for idx, err := range e {
+		msgs[idx] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Run type checks the program and runs it until it ends, exits, fails or ctx
+// is done. The type errors are returned as TypeErrors, the error which stopped
+// the program as an *ast.RuntimeError, and a non-zero exit status as an
+// *ast.ExitError.
+func Run(ctx context.Context, program ast.Node, opts Options) error {
+	checker := ast.NewChecker()
+	checker.Strict = opts.Strict
+	if errs := checker.CheckProgram(program); len(errs) > 0 {
+		return TypeErrors(errs)
+	}
+
+	stdin, stdout, stderr := opts.Stdin, opts.Stdout, opts.Stderr
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	var err error
+	switch opts.Engine {
+	case "", "tree":
+		variablesTable := interfaces.MakeVariablesTable()
+		variablesTable.Strict = opts.Strict
+		interpreter := &ast.Interpreter{
+			VariablesTable: &variablesTable,
+			Context:        ctx,
+			Stdin:          stdin,
+			Stdout:         stdout,
+			Overflow:       opts.Overflow,
+		}
+		_, err = program.Interpret(interpreter)
+	case "vm":
+		var compiled *vm.Program
+		if compiled, err = vm.Compile(program); err != nil {
+			return err
+		}
+		err = vm.Run(ctx, compiled, vm.Options{Stdin: stdin, Stdout: stdout, Overflow: opts.Overflow})
+	default:
+		return fmt.Errorf("unknown engine %s, use tree or vm", opts.Engine)
+	}
+	var exit *ast.ExitError
+	if errors.As(err, &exit) {
+		if exit.Status == 0 {
+			return nil
+		}
+		return exit
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, ast.Report(err))
+	}
+	return err
+}
+
+// ExitCode is the exit status of a program which Run returned the error for.
+// The program chose it with exit, or it is 1 for any other error.
+func ExitCode(err error) int {
+	var exit *ast.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exit):
+		return exit.Status
+	}
+	return 1
+}
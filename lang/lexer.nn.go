@@ -1,4 +1,4 @@
-package main
+package lang
 
 import (
 	"fmt"
@@ -184,7 +184,7 @@ type dfa struct {
 }
 
 var dfas = []dfa{
-	// [ \t\n]
+	// [ \t\n\r]
 	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
@@ -192,6 +192,8 @@ var dfas = []dfa{
 				return 1
 			case 10:
 				return 1
+			case 13:
+				return 1
 			case 32:
 				return 1
 			}
@@ -203,6 +205,8 @@ var dfas = []dfa{
 				return -1
 			case 10:
 				return -1
+			case 13:
+				return -1
 			case 32:
 				return -1
 			}
@@ -210,254 +214,575 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
-	// \(
-	{[]bool{false, true}, []func(rune) int{ // Transitions
+	// \/\/[^\n]*
+	{[]bool{false, false, true, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 40:
+			case 10:
+				return -1
+			case 47:
 				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 40:
+			case 10:
 				return -1
+			case 47:
+				return 2
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
-
-	// \)
-	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 41:
-				return 1
+			case 10:
+				return -1
+			case 47:
+				return 3
 			}
-			return -1
+			return 3
 		},
 		func(r rune) int {
 			switch r {
-			case 41:
+			case 10:
 				return -1
+			case 47:
+				return 3
 			}
-			return -1
+			return 3
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
 
-	// \+
-	{[]bool{false, true}, []func(rune) int{ // Transitions
+	// \{[^}]*\}
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 43:
+			case 123:
 				return 1
+			case 125:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 43:
+			case 123:
+				return 2
+			case 125:
+				return 3
+			}
+			return 2
+		},
+		func(r rune) int {
+			switch r {
+			case 123:
+				return 2
+			case 125:
+				return 3
+			}
+			return 2
+		},
+		func(r rune) int {
+			switch r {
+			case 123:
+				return -1
+			case 125:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
 
-	// \-
-	{[]bool{false, true}, []func(rune) int{ // Transitions
+	// \(\*([^*]|\*+[^*)])*\*+\)
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 45:
+			case 40:
 				return 1
+			case 41:
+				return -1
+			case 42:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 45:
+			case 40:
+				return -1
+			case 41:
 				return -1
+			case 42:
+				return 2
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
-
-	// \*
-	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
+			case 40:
+				return 3
+			case 41:
+				return 3
 			case 42:
-				return 1
+				return 4
 			}
-			return -1
+			return 3
+		},
+		func(r rune) int {
+			switch r {
+			case 40:
+				return 3
+			case 41:
+				return 3
+			case 42:
+				return 4
+			}
+			return 3
+		},
+		func(r rune) int {
+			switch r {
+			case 40:
+				return 5
+			case 41:
+				return 6
+			case 42:
+				return 4
+			}
+			return 5
+		},
+		func(r rune) int {
+			switch r {
+			case 40:
+				return 3
+			case 41:
+				return 3
+			case 42:
+				return 4
+			}
+			return 3
 		},
 		func(r rune) int {
 			switch r {
+			case 40:
+				return -1
+			case 41:
+				return -1
 			case 42:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
-	// \/
-	{[]bool{false, true}, []func(rune) int{ // Transitions
+	// \{[^}]*|\(\*([^*]|\*+[^*)])*\**
+	{[]bool{false, false, true, true, true, true, true, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 47:
+			case 40:
 				return 1
+			case 41:
+				return -1
+			case 42:
+				return -1
+			case 123:
+				return 2
+			case 125:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 47:
+			case 40:
+				return -1
+			case 41:
+				return -1
+			case 42:
+				return 3
+			case 123:
+				return -1
+			case 125:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
-
-	// \%
-	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 37:
-				return 1
+			case 40:
+				return 4
+			case 41:
+				return 4
+			case 42:
+				return 4
+			case 123:
+				return 4
+			case 125:
+				return -1
 			}
-			return -1
+			return 4
 		},
 		func(r rune) int {
 			switch r {
-			case 37:
+			case 40:
+				return 5
+			case 41:
+				return 5
+			case 42:
+				return 6
+			case 123:
+				return 5
+			case 125:
+				return 5
+			}
+			return 5
+		},
+		func(r rune) int {
+			switch r {
+			case 40:
+				return 4
+			case 41:
+				return 4
+			case 42:
+				return 4
+			case 123:
+				return 4
+			case 125:
 				return -1
 			}
-			return -1
+			return 4
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
-
-	// ,
-	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 44:
-				return 1
+			case 40:
+				return 5
+			case 41:
+				return 5
+			case 42:
+				return 6
+			case 123:
+				return 5
+			case 125:
+				return 5
 			}
-			return -1
+			return 5
 		},
 		func(r rune) int {
 			switch r {
-			case 44:
+			case 40:
+				return 7
+			case 41:
 				return -1
+			case 42:
+				return 6
+			case 123:
+				return 7
+			case 125:
+				return 7
 			}
-			return -1
+			return 7
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+		func(r rune) int {
+			switch r {
+			case 40:
+				return 5
+			case 41:
+				return 5
+			case 42:
+				return 6
+			case 123:
+				return 5
+			case 125:
+				return 5
+			}
+			return 5
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 
-	// ;
+	// \(
 	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 59:
+			case 40:
 				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 59:
+			case 40:
 				return -1
 			}
 			return -1
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
-	// =
+	// \)
 	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 61:
+			case 41:
 				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 61:
+			case 41:
 				return -1
 			}
 			return -1
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
-	// <>
-	{[]bool{false, false, true}, []func(rune) int{ // Transitions
+	// \[
+	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 60:
+			case 91:
 				return 1
-			case 62:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 60:
+			case 91:
 				return -1
-			case 62:
-				return 2
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// \]
+	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 60:
-				return -1
-			case 62:
+			case 93:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 93:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
-	// <
+	// \+
 	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 60:
+			case 43:
 				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 60:
+			case 43:
 				return -1
 			}
 			return -1
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
-	// >
+	// \-
 	{[]bool{false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 62:
+			case 45:
 				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 62:
+			case 45:
 				return -1
 			}
 			return -1
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
-	// <=
+	// \*
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 42:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 42:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// \/
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 47:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 47:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// \%
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 37:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 37:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// ,
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 44:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 44:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// ;
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 59:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 59:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// =
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 61:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 61:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// <>
+	{[]bool{false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 60:
+				return 1
+			case 62:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 60:
+				return -1
+			case 62:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 60:
+				return -1
+			case 62:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
+
+	// <
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 60:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 60:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// >
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 62:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 62:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// <=
 	{[]bool{false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
@@ -654,6 +979,54 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
 
+	// xor
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 120:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 111:
+				return 2
+			case 114:
+				return -1
+			case 120:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 111:
+				return -1
+			case 114:
+				return 3
+			case 120:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 120:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
 	// not
 	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -1118,19 +1491,19 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 
-	// length
+	// printf
 	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 101:
+			case 102:
 				return -1
-			case 103:
+			case 105:
 				return -1
-			case 104:
+			case 110:
 				return -1
-			case 108:
+			case 112:
 				return 1
-			case 110:
+			case 114:
 				return -1
 			case 116:
 				return -1
@@ -1139,16 +1512,16 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 101:
-				return 2
-			case 103:
-				return -1
-			case 104:
+			case 102:
 				return -1
-			case 108:
+			case 105:
 				return -1
 			case 110:
 				return -1
+			case 112:
+				return -1
+			case 114:
+				return 2
 			case 116:
 				return -1
 			}
@@ -1156,13 +1529,230 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 101:
+			case 102:
 				return -1
-			case 103:
+			case 105:
+				return 3
+			case 110:
 				return -1
-			case 104:
+			case 112:
 				return -1
-			case 108:
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 4
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return 5
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return 6
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// write
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return 2
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 105:
+				return 3
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return 4
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return 5
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// length
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 104:
+				return -1
+			case 108:
+				return 1
+			case 110:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return 2
+			case 103:
+				return -1
+			case 104:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 104:
+				return -1
+			case 108:
 				return -1
 			case 110:
 				return 3
@@ -1262,101 +1852,1601 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return -1
-			case 110:
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 2
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return 3
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return 4
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 5
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return 6
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 7
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return 8
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// concatenate
+	{[]bool{false, false, false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return 1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 2
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return 3
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return 4
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return 5
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return 6
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 7
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return 8
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return 9
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return 10
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 11
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// substring
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return 1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return 3
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return 4
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 5
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return 6
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return 7
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 8
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return 9
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// readint
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return 1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 2
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return 3
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return 4
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return 5
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 6
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return 7
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// readstr
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 114:
+				return 1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 2
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return 3
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return 4
+			case 101:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return 5
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 6
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 114:
+				return 7
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// readln
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 2
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return 3
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return 4
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return 5
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return 6
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// eof
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 101:
+				return 1
+			case 102:
+				return -1
+			case 111:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 111:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 102:
+				return 3
+			case 111:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 111:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// str
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 114:
+				return -1
+			case 115:
+				return 1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 114:
+				return 3
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// int
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 105:
+				return 1
+			case 110:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return 2
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 116:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// isint
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 105:
+				return 1
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 115:
+				return 2
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return 3
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return 4
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 5
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// chr
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 99:
+				return 1
+			case 104:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 99:
+				return -1
+			case 104:
+				return 2
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 99:
+				return -1
+			case 104:
+				return -1
+			case 114:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 99:
+				return -1
+			case 104:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// ord
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 100:
+				return -1
+			case 111:
+				return 1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
+				return 3
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// begin
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 98:
+				return 1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 101:
+				return 2
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return 3
+			case 105:
+				return -1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return 4
+			case 110:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 5
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 98:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// end
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 100:
+				return -1
+			case 101:
+				return 1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
+				return 3
+			case 101:
+				return -1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// for
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 102:
+				return 1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return -1
+			case 111:
+				return 2
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 102:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// while
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 119:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 101:
 				return -1
-			case 111:
+			case 104:
 				return 2
-			case 112:
+			case 105:
 				return -1
-			case 115:
+			case 108:
 				return -1
-			case 116:
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return -1
-			case 110:
-				return -1
-			case 111:
+			case 101:
 				return -1
-			case 112:
+			case 104:
 				return -1
-			case 115:
+			case 105:
 				return 3
-			case 116:
+			case 108:
+				return -1
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return 4
-			case 110:
-				return -1
-			case 111:
+			case 101:
 				return -1
-			case 112:
+			case 104:
 				return -1
-			case 115:
+			case 105:
 				return -1
-			case 116:
+			case 108:
+				return 4
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return -1
-			case 110:
+			case 101:
+				return 5
+			case 104:
 				return -1
-			case 111:
+			case 105:
 				return -1
-			case 112:
+			case 108:
 				return -1
-			case 115:
+			case 119:
 				return -1
-			case 116:
-				return 5
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return 6
-			case 110:
+			case 101:
 				return -1
-			case 111:
+			case 104:
 				return -1
-			case 112:
+			case 105:
 				return -1
-			case 115:
+			case 108:
 				return -1
-			case 116:
+			case 119:
 				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// repeat
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 105:
+			case 97:
 				return -1
-			case 110:
+			case 101:
 				return -1
-			case 111:
-				return 7
 			case 112:
 				return -1
-			case 115:
-				return -1
+			case 114:
+				return 1
 			case 116:
 				return -1
 			}
@@ -1364,15 +3454,13 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return -1
-			case 110:
-				return 8
-			case 111:
+			case 97:
 				return -1
+			case 101:
+				return 2
 			case 112:
 				return -1
-			case 115:
+			case 114:
 				return -1
 			case 116:
 				return -1
@@ -1381,36 +3469,28 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 105:
-				return -1
-			case 110:
+			case 97:
 				return -1
-			case 111:
+			case 101:
 				return -1
 			case 112:
-				return -1
-			case 115:
+				return 3
+			case 114:
 				return -1
 			case 116:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
-	// concatenate
-	{[]bool{false, false, false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 97:
 				return -1
-			case 99:
-				return 1
 			case 101:
+				return 4
+			case 112:
 				return -1
-			case 110:
-				return -1
-			case 111:
+			case 114:
 				return -1
 			case 116:
 				return -1
@@ -1420,15 +3500,13 @@ var dfas = []dfa{
 		func(r rune) int {
 			switch r {
 			case 97:
-				return -1
-			case 99:
-				return -1
+				return 5
 			case 101:
 				return -1
-			case 110:
+			case 112:
+				return -1
+			case 114:
 				return -1
-			case 111:
-				return 2
 			case 116:
 				return -1
 			}
@@ -1438,16 +3516,14 @@ var dfas = []dfa{
 			switch r {
 			case 97:
 				return -1
-			case 99:
-				return -1
 			case 101:
 				return -1
-			case 110:
-				return 3
-			case 111:
+			case 112:
 				return -1
-			case 116:
+			case 114:
 				return -1
+			case 116:
+				return 6
 			}
 			return -1
 		},
@@ -1455,133 +3531,128 @@ var dfas = []dfa{
 			switch r {
 			case 97:
 				return -1
-			case 99:
-				return 4
 			case 101:
 				return -1
-			case 110:
+			case 112:
 				return -1
-			case 111:
+			case 114:
 				return -1
 			case 116:
 				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// until
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 97:
-				return 5
-			case 99:
+			case 105:
 				return -1
-			case 101:
+			case 108:
 				return -1
 			case 110:
 				return -1
-			case 111:
-				return -1
 			case 116:
 				return -1
+			case 117:
+				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 99:
+			case 105:
 				return -1
-			case 101:
+			case 108:
 				return -1
 			case 110:
+				return 2
+			case 116:
 				return -1
-			case 111:
+			case 117:
 				return -1
-			case 116:
-				return 6
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 105:
 				return -1
-			case 99:
+			case 108:
 				return -1
-			case 101:
-				return 7
 			case 110:
 				return -1
-			case 111:
-				return -1
 			case 116:
+				return 3
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 99:
-				return -1
-			case 101:
+			case 105:
+				return 4
+			case 108:
 				return -1
 			case 110:
-				return 8
-			case 111:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return 9
-			case 99:
-				return -1
-			case 101:
+			case 105:
 				return -1
+			case 108:
+				return 5
 			case 110:
 				return -1
-			case 111:
-				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 99:
+			case 105:
 				return -1
-			case 101:
+			case 108:
 				return -1
 			case 110:
 				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// to
+	{[]bool{false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
 			case 111:
 				return -1
 			case 116:
-				return 10
+				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 99:
-				return -1
-			case 101:
-				return 11
-			case 110:
-				return -1
 			case 111:
-				return -1
+				return 2
 			case 116:
 				return -1
 			}
@@ -1589,14 +3660,6 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 99:
-				return -1
-			case 101:
-				return -1
-			case 110:
-				return -1
 			case 111:
 				return -1
 			case 116:
@@ -1604,191 +3667,146 @@ var dfas = []dfa{
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
 
-	// substring
-	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+	// do
+	{[]bool{false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 98:
-				return -1
-			case 103:
-				return -1
-			case 105:
-				return -1
-			case 110:
-				return -1
-			case 114:
-				return -1
-			case 115:
+			case 100:
 				return 1
-			case 116:
+			case 111:
 				return -1
-			case 117:
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 100:
 				return -1
+			case 111:
+				return 2
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
-				return -1
-			case 103:
-				return -1
-			case 105:
-				return -1
-			case 110:
-				return -1
-			case 114:
-				return -1
-			case 115:
+			case 100:
 				return -1
-			case 116:
+			case 111:
 				return -1
-			case 117:
-				return 2
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
+
+	// break
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 98:
-				return 3
-			case 103:
+			case 97:
 				return -1
-			case 105:
+			case 98:
+				return 1
+			case 101:
 				return -1
-			case 110:
+			case 107:
 				return -1
 			case 114:
 				return -1
-			case 115:
-				return -1
-			case 116:
-				return -1
-			case 117:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 97:
 				return -1
-			case 103:
+			case 98:
 				return -1
-			case 105:
+			case 101:
 				return -1
-			case 110:
+			case 107:
 				return -1
 			case 114:
-				return -1
-			case 115:
-				return 4
-			case 116:
-				return -1
-			case 117:
-				return -1
+				return 2
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
-				return -1
-			case 103:
+			case 97:
 				return -1
-			case 105:
+			case 98:
 				return -1
-			case 110:
+			case 101:
+				return 3
+			case 107:
 				return -1
 			case 114:
 				return -1
-			case 115:
-				return -1
-			case 116:
-				return 5
-			case 117:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
+			case 97:
+				return 4
 			case 98:
 				return -1
-			case 103:
-				return -1
-			case 105:
+			case 101:
 				return -1
-			case 110:
+			case 107:
 				return -1
 			case 114:
-				return 6
-			case 115:
-				return -1
-			case 116:
-				return -1
-			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 97:
 				return -1
-			case 103:
+			case 98:
 				return -1
-			case 105:
-				return 7
-			case 110:
+			case 101:
 				return -1
+			case 107:
+				return 5
 			case 114:
 				return -1
-			case 115:
-				return -1
-			case 116:
-				return -1
-			case 117:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
+			case 97:
+				return -1
 			case 98:
 				return -1
-			case 103:
+			case 101:
 				return -1
-			case 105:
+			case 107:
 				return -1
-			case 110:
-				return 8
 			case 114:
 				return -1
-			case 115:
-				return -1
-			case 116:
-				return -1
-			case 117:
-				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// continue
+	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
+				return 1
+			case 101:
 				return -1
-			case 103:
-				return 9
 			case 105:
 				return -1
 			case 110:
 				return -1
-			case 114:
-				return -1
-			case 115:
+			case 111:
 				return -1
 			case 116:
 				return -1
@@ -1799,18 +3817,16 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
 				return -1
-			case 103:
+			case 101:
 				return -1
 			case 105:
 				return -1
 			case 110:
 				return -1
-			case 114:
-				return -1
-			case 115:
-				return -1
+			case 111:
+				return 2
 			case 116:
 				return -1
 			case 117:
@@ -1818,129 +3834,123 @@ var dfas = []dfa{
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
-	// readint
-	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
 			case 105:
 				return -1
 			case 110:
+				return 3
+			case 111:
 				return -1
-			case 114:
-				return 1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
-				return 2
+				return -1
 			case 105:
 				return -1
 			case 110:
 				return -1
-			case 114:
+			case 111:
 				return -1
 			case 116:
+				return 4
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return 3
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
 			case 105:
-				return -1
+				return 5
 			case 110:
 				return -1
-			case 114:
+			case 111:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 99:
 				return -1
-			case 100:
-				return 4
 			case 101:
 				return -1
 			case 105:
 				return -1
 			case 110:
-				return -1
-			case 114:
+				return 6
+			case 111:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
 			case 105:
-				return 5
+				return -1
 			case 110:
 				return -1
-			case 114:
+			case 111:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return 7
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
-				return -1
+				return 8
 			case 105:
 				return -1
 			case 110:
-				return 6
-			case 114:
+				return -1
+			case 111:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
@@ -1948,135 +3958,107 @@ var dfas = []dfa{
 				return -1
 			case 110:
 				return -1
-			case 114:
+			case 111:
 				return -1
 			case 116:
-				return 7
+				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// exit
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
-				return -1
 			case 101:
-				return -1
+				return 1
 			case 105:
 				return -1
-			case 110:
-				return -1
-			case 114:
-				return -1
 			case 116:
 				return -1
+			case 120:
+				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
-	// readstr
-	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
-				return -1
 			case 101:
 				return -1
-			case 114:
-				return 1
-			case 115:
+			case 105:
 				return -1
 			case 116:
 				return -1
+			case 120:
+				return 2
 			}
 			return -1
 		},
-		func(r rune) int {
-			switch r {
-			case 97:
-				return -1
-			case 100:
-				return -1
-			case 101:
-				return 2
-			case 114:
-				return -1
-			case 115:
+		func(r rune) int {
+			switch r {
+			case 101:
 				return -1
+			case 105:
+				return 3
 			case 116:
 				return -1
+			case 120:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return 3
-			case 100:
-				return -1
 			case 101:
 				return -1
-			case 114:
-				return -1
-			case 115:
+			case 105:
 				return -1
 			case 116:
+				return 4
+			case 120:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
-				return -1
-			case 100:
-				return 4
 			case 101:
 				return -1
-			case 114:
-				return -1
-			case 115:
+			case 105:
 				return -1
 			case 116:
 				return -1
+			case 120:
+				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+
+	// var
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 97:
 				return -1
-			case 100:
-				return -1
-			case 101:
-				return -1
 			case 114:
 				return -1
-			case 115:
-				return 5
-			case 116:
-				return -1
+			case 118:
+				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
 			case 97:
-				return -1
-			case 100:
-				return -1
-			case 101:
-				return -1
+				return 2
 			case 114:
 				return -1
-			case 115:
+			case 118:
 				return -1
-			case 116:
-				return 6
 			}
 			return -1
 		},
@@ -2084,15 +4066,9 @@ var dfas = []dfa{
 			switch r {
 			case 97:
 				return -1
-			case 100:
-				return -1
-			case 101:
-				return -1
 			case 114:
-				return 7
-			case 115:
-				return -1
-			case 116:
+				return 3
+			case 118:
 				return -1
 			}
 			return -1
@@ -2101,170 +4077,180 @@ var dfas = []dfa{
 			switch r {
 			case 97:
 				return -1
-			case 100:
-				return -1
-			case 101:
-				return -1
 			case 114:
 				return -1
-			case 115:
-				return -1
-			case 116:
+			case 118:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
 
-	// begin
+	// const
 	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
 				return 1
-			case 101:
+			case 110:
 				return -1
-			case 103:
+			case 111:
 				return -1
-			case 105:
+			case 115:
 				return -1
-			case 110:
+			case 116:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
 				return -1
-			case 101:
-				return 2
-			case 103:
+			case 110:
 				return -1
-			case 105:
+			case 111:
+				return 2
+			case 115:
 				return -1
-			case 110:
+			case 116:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
-				return -1
-			case 101:
+			case 99:
 				return -1
-			case 103:
+			case 110:
 				return 3
-			case 105:
+			case 111:
 				return -1
-			case 110:
+			case 115:
+				return -1
+			case 116:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
 				return -1
-			case 101:
+			case 110:
 				return -1
-			case 103:
+			case 111:
 				return -1
-			case 105:
+			case 115:
 				return 4
-			case 110:
+			case 116:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
 				return -1
-			case 101:
+			case 110:
 				return -1
-			case 103:
+			case 111:
 				return -1
-			case 105:
+			case 115:
 				return -1
-			case 110:
+			case 116:
 				return 5
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 98:
+			case 99:
 				return -1
-			case 101:
+			case 110:
 				return -1
-			case 103:
+			case 111:
 				return -1
-			case 105:
+			case 115:
 				return -1
-			case 110:
+			case 116:
 				return -1
 			}
 			return -1
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 
-	// end
-	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+	// pragma
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 100:
+			case 97:
 				return -1
-			case 101:
+			case 103:
+				return -1
+			case 109:
+				return -1
+			case 112:
 				return 1
-			case 110:
+			case 114:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 100:
+			case 97:
 				return -1
-			case 101:
+			case 103:
 				return -1
-			case 110:
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
 				return 2
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 100:
+			case 97:
 				return 3
-			case 101:
+			case 103:
 				return -1
-			case 110:
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 100:
+			case 97:
 				return -1
-			case 101:
+			case 103:
+				return 4
+			case 109:
 				return -1
-			case 110:
+			case 112:
+				return -1
+			case 114:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
-
-	// for
-	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 102:
-				return 1
-			case 111:
+			case 97:
+				return -1
+			case 103:
+				return -1
+			case 109:
+				return 5
+			case 112:
 				return -1
 			case 114:
 				return -1
@@ -2273,10 +4259,14 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 102:
+			case 97:
+				return 6
+			case 103:
+				return -1
+			case 109:
+				return -1
+			case 112:
 				return -1
-			case 111:
-				return 2
 			case 114:
 				return -1
 			}
@@ -2284,199 +4274,268 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
+			case 97:
+				return -1
+			case 103:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// function
+	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 99:
+				return -1
 			case 102:
+				return 1
+			case 105:
+				return -1
+			case 110:
 				return -1
 			case 111:
 				return -1
-			case 114:
-				return 3
+			case 116:
+				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
+			case 99:
+				return -1
 			case 102:
 				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
 			case 111:
 				return -1
-			case 114:
+			case 116:
 				return -1
+			case 117:
+				return 2
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
-
-	// to
-	{[]bool{false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
+			case 99:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 3
 			case 111:
 				return -1
 			case 116:
-				return 1
+				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
+			case 99:
+				return 4
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
 			case 111:
-				return 2
+				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
+			case 99:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
 			case 111:
 				return -1
 			case 116:
+				return 5
+			case 117:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
-
-	// do
-	{[]bool{false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 100:
-				return 1
-			case 111:
+			case 99:
 				return -1
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 100:
+			case 102:
 				return -1
-			case 111:
-				return 2
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 100:
+			case 105:
+				return 6
+			case 110:
 				return -1
 			case 111:
 				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
-
-	// break
-	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 99:
 				return -1
-			case 98:
-				return 1
-			case 101:
+			case 102:
 				return -1
-			case 107:
+			case 105:
 				return -1
-			case 114:
+			case 110:
+				return -1
+			case 111:
+				return 7
+			case 116:
+				return -1
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 99:
 				return -1
-			case 98:
+			case 102:
 				return -1
-			case 101:
+			case 105:
 				return -1
-			case 107:
+			case 110:
+				return 8
+			case 111:
+				return -1
+			case 116:
+				return -1
+			case 117:
 				return -1
-			case 114:
-				return 2
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 99:
 				return -1
-			case 98:
+			case 102:
 				return -1
-			case 101:
-				return 3
-			case 107:
+			case 105:
 				return -1
-			case 114:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 116:
+				return -1
+			case 117:
 				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// procedure
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 97:
-				return 4
-			case 98:
+			case 99:
+				return -1
+			case 100:
 				return -1
 			case 101:
 				return -1
-			case 107:
+			case 111:
 				return -1
+			case 112:
+				return 1
 			case 114:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 99:
 				return -1
-			case 98:
+			case 100:
 				return -1
 			case 101:
 				return -1
-			case 107:
-				return 5
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 114:
+				return 2
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 97:
+			case 99:
 				return -1
-			case 98:
+			case 100:
 				return -1
 			case 101:
 				return -1
-			case 107:
+			case 111:
+				return 3
+			case 112:
 				return -1
 			case 114:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
-
-	// continue
-	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 99:
-				return 1
-			case 101:
-				return -1
-			case 105:
+				return 4
+			case 100:
 				return -1
-			case 110:
+			case 101:
 				return -1
 			case 111:
 				return -1
-			case 116:
+			case 112:
+				return -1
+			case 114:
 				return -1
 			case 117:
 				return -1
@@ -2487,15 +4546,15 @@ var dfas = []dfa{
 			switch r {
 			case 99:
 				return -1
-			case 101:
+			case 100:
 				return -1
-			case 105:
+			case 101:
+				return 5
+			case 111:
 				return -1
-			case 110:
+			case 112:
 				return -1
-			case 111:
-				return 2
-			case 116:
+			case 114:
 				return -1
 			case 117:
 				return -1
@@ -2506,15 +4565,15 @@ var dfas = []dfa{
 			switch r {
 			case 99:
 				return -1
+			case 100:
+				return 6
 			case 101:
 				return -1
-			case 105:
-				return -1
-			case 110:
-				return 3
 			case 111:
 				return -1
-			case 116:
+			case 112:
+				return -1
+			case 114:
 				return -1
 			case 117:
 				return -1
@@ -2525,18 +4584,18 @@ var dfas = []dfa{
 			switch r {
 			case 99:
 				return -1
+			case 100:
+				return -1
 			case 101:
 				return -1
-			case 105:
+			case 111:
 				return -1
-			case 110:
+			case 112:
 				return -1
-			case 111:
+			case 114:
 				return -1
-			case 116:
-				return 4
 			case 117:
-				return -1
+				return 7
 			}
 			return -1
 		},
@@ -2544,16 +4603,16 @@ var dfas = []dfa{
 			switch r {
 			case 99:
 				return -1
-			case 101:
+			case 100:
 				return -1
-			case 105:
-				return 5
-			case 110:
+			case 101:
 				return -1
 			case 111:
 				return -1
-			case 116:
+			case 112:
 				return -1
+			case 114:
+				return 8
 			case 117:
 				return -1
 			}
@@ -2563,15 +4622,15 @@ var dfas = []dfa{
 			switch r {
 			case 99:
 				return -1
-			case 101:
-				return -1
-			case 105:
+			case 100:
 				return -1
-			case 110:
-				return 6
+			case 101:
+				return 9
 			case 111:
 				return -1
-			case 116:
+			case 112:
+				return -1
+			case 114:
 				return -1
 			case 117:
 				return -1
@@ -2582,33 +4641,33 @@ var dfas = []dfa{
 			switch r {
 			case 99:
 				return -1
-			case 101:
-				return -1
-			case 105:
+			case 100:
 				return -1
-			case 110:
+			case 101:
 				return -1
 			case 111:
 				return -1
-			case 116:
+			case 112:
+				return -1
+			case 114:
 				return -1
 			case 117:
-				return 7
+				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// return
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 99:
-				return -1
 			case 101:
-				return 8
-			case 105:
 				return -1
 			case 110:
 				return -1
-			case 111:
-				return -1
+			case 114:
+				return 1
 			case 116:
 				return -1
 			case 117:
@@ -2618,15 +4677,11 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 99:
-				return -1
 			case 101:
-				return -1
-			case 105:
-				return -1
+				return 2
 			case 110:
 				return -1
-			case 111:
+			case 114:
 				return -1
 			case 116:
 				return -1
@@ -2635,19 +4690,17 @@ var dfas = []dfa{
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
-	// exit
-	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 101:
-				return 1
-			case 105:
 				return -1
-			case 116:
+			case 110:
 				return -1
-			case 120:
+			case 114:
+				return -1
+			case 116:
+				return 3
+			case 117:
 				return -1
 			}
 			return -1
@@ -2656,12 +4709,14 @@ var dfas = []dfa{
 			switch r {
 			case 101:
 				return -1
-			case 105:
+			case 110:
+				return -1
+			case 114:
 				return -1
 			case 116:
 				return -1
-			case 120:
-				return 2
+			case 117:
+				return 4
 			}
 			return -1
 		},
@@ -2669,11 +4724,13 @@ var dfas = []dfa{
 			switch r {
 			case 101:
 				return -1
-			case 105:
-				return 3
+			case 110:
+				return -1
+			case 114:
+				return 5
 			case 116:
 				return -1
-			case 120:
+			case 117:
 				return -1
 			}
 			return -1
@@ -2682,11 +4739,13 @@ var dfas = []dfa{
 			switch r {
 			case 101:
 				return -1
-			case 105:
+			case 110:
+				return 6
+			case 114:
 				return -1
 			case 116:
-				return 4
-			case 120:
+				return -1
+			case 117:
 				return -1
 			}
 			return -1
@@ -2695,16 +4754,18 @@ var dfas = []dfa{
 			switch r {
 			case 101:
 				return -1
-			case 105:
+			case 110:
+				return -1
+			case 114:
 				return -1
 			case 116:
 				return -1
-			case 120:
+			case 117:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
 	// :=
 	{[]bool{false, false, true}, []func(rune) int{ // Transitions
@@ -2737,8 +4798,26 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1}, nil},
 
-	// "(\\.|[^"])*"
-	{[]bool{false, false, true, false, false, true, false, false}, []func(rune) int{ // Transitions
+	// :
+	{[]bool{false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 58:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 58:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
+
+	// "(\\.|[^"\\])*"
+	{[]bool{false, false, true, false, false, false}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 34:
@@ -2771,18 +4850,9 @@ var dfas = []dfa{
 			case 34:
 				return 5
 			case 92:
-				return 6
-			}
-			return 7
-		},
-		func(r rune) int {
-			switch r {
-			case 34:
-				return 2
-			case 92:
-				return 3
+				return 5
 			}
-			return 4
+			return 5
 		},
 		func(r rune) int {
 			switch r {
@@ -2793,15 +4863,6 @@ var dfas = []dfa{
 			}
 			return 4
 		},
-		func(r rune) int {
-			switch r {
-			case 34:
-				return 5
-			case 92:
-				return 6
-			}
-			return 7
-		},
 		func(r rune) int {
 			switch r {
 			case 34:
@@ -2811,7 +4872,7 @@ var dfas = []dfa{
 			}
 			return 4
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 
 	// [a-zA-Z][a-zA-Z0-9]*
 	{[]bool{false, true, true}, []func(rune) int{ // Transitions
@@ -2959,254 +5020,414 @@ OUTER0:
 		switch yylex.next(0) {
 		case 0:
 			{ /* Skip spaces and tabs. */
+				yylex.skip()
 			}
 		case 1:
+			{
+				yylex.comment()
+			}
+		case 2:
+			{
+				yylex.comment()
+			}
+		case 3:
+			{
+				yylex.comment()
+			}
+		case 4:
+			{
+				// an unterminated comment runs to the end of the source
+				yylex.pos(lval) // our pos
+				lp := yylex.cast()
+				lp.token.invalid = true
+				lp.lexerErrs = append(lp.lexerErrs, &LexParseErr{
+					Err:      Error("unterminated comment"),
+					Row:      yylex.Line(),
+					Col:      yylex.Column(),
+					Filename: lp.filename,
+					Lexer:    true,
+				})
+				return ERROR
+			}
+		case 5:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return OPEN_PAREN
 			}
-		case 2:
+		case 6:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return CLOSE_PAREN
 			}
-		case 3:
+		case 7:
+			{
+				yylex.pos(lval) // our pos
+				lval.str = yylex.Text()
+				return OPEN_BRACKET
+			}
+		case 8:
+			{
+				yylex.pos(lval) // our pos
+				lval.str = yylex.Text()
+				return CLOSE_BRACKET
+			}
+		case 9:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return PLUS
 			}
-		case 4:
+		case 10:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return MINUS
 			}
-		case 5:
+		case 11:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return MULTIPLY
 			}
-		case 6:
+		case 12:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return DIVIDE
 			}
-		case 7:
+		case 13:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return MOD
 			}
-		case 8:
+		case 14:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return COMMA
 			}
-		case 9:
+		case 15:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return SEMICOLON
 			}
-		case 10:
+		case 16:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return EQ
 			}
-		case 11:
+		case 17:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return NEQ
 			}
-		case 12:
+		case 18:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return LT
 			}
-		case 13:
+		case 19:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return GT
 			}
-		case 14:
+		case 20:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return LTE
 			}
-		case 15:
+		case 21:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return GTE
 			}
-		case 16:
+		case 22:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return STR_EQ
 			}
-		case 17:
+		case 23:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return STR_NEQ
 			}
-		case 18:
+		case 24:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return AND
 			}
-		case 19:
+		case 25:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return OR
 			}
-		case 20:
+		case 26:
+			{
+				yylex.pos(lval) // our pos
+				lval.str = yylex.Text()
+				return XOR
+			}
+		case 27:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return NOT
 			}
-		case 21:
+		case 28:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return TRUE
 			}
-		case 22:
+		case 29:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return FALSE
 			}
-		case 23:
+		case 30:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return IF
 			}
-		case 24:
+		case 31:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return THEN
 			}
-		case 25:
+		case 32:
 			{
 				yylex.pos(lval) // our pos
 				lval.str = yylex.Text()
 				return ELSE
 			}
-		case 26:
+		case 33:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_PRINT
 			}
-		case 27:
+		case 34:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_PRINTF
+			}
+		case 35:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_WRITE
+			}
+		case 36:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_LENGTH
 			}
-		case 28:
+		case 37:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_POSITION
 			}
-		case 29:
+		case 38:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_CONCATENATE
 			}
-		case 30:
+		case 39:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_SUBSTRING
 			}
-		case 31:
+		case 40:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_READINT
 			}
-		case 32:
+		case 41:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FN_READSTR
 			}
-		case 33:
+		case 42:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_READLN
+			}
+		case 43:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_EOF
+			}
+		case 44:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_STR
+			}
+		case 45:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_INT
+			}
+		case 46:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_ISINT
+			}
+		case 47:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_CHR
+			}
+		case 48:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FN_ORD
+			}
+		case 49:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return BEGIN
 			}
-		case 34:
+		case 50:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return END
 			}
-		case 35:
+		case 51:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return FOR
 			}
-		case 36:
+		case 52:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return WHILE
+			}
+		case 53:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return REPEAT
+			}
+		case 54:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return UNTIL
+			}
+		case 55:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return TO
 			}
-		case 37:
+		case 56:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return DO
 			}
-		case 38:
+		case 57:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return BREAK
 			}
-		case 39:
+		case 58:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return CONTINUE
 			}
-		case 40:
+		case 59:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return EXIT
 			}
-		case 41:
+		case 60:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return VAR
+			}
+		case 61:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return CONST
+			}
+		case 62:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return PRAGMA
+			}
+		case 63:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return FUNCTION
+			}
+		case 64:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return PROCEDURE
+			}
+		case 65:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return RETURN
+			}
+		case 66:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return ASSIGN
 			}
-		case 42:
+		case 67:
+			{
+				yylex.pos(lval)
+				lval.str = yylex.Text()
+				return COLON
+			}
+		case 68:
 			{
 				yylex.pos(lval) // our pos
 				s := yylex.Text()
@@ -3215,36 +5436,47 @@ OUTER0:
 					panic(fmt.Sprintf("error lexing STRING, got: %s", s))
 				}
 
-				lval.str = s[1 : len(s)-1] // remove the two quotes
+				// remove the two quotes and decode the escapes
+				str, err := unescape(s[1 : len(s)-1])
+				if err != nil {
+					lp := yylex.cast()
+					lp.lexerErrs = append(lp.lexerErrs, &LexParseErr{
+						Err:      Error(err.Error()),
+						Str:      s,
+						Row:      yylex.Line(),
+						Col:      yylex.Column(),
+						Filename: lp.filename,
+						Lexer:    true,
+					})
+				}
+				lval.str = str
 				return STRING
 			}
-		case 43:
+		case 69:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return IDENT
 			}
-		case 44:
+		case 70:
 			{
 				yylex.pos(lval)
 				lval.str = yylex.Text()
 				return NUM
 			}
-		case 45:
+		case 71:
 			{
 				yylex.pos(lval) // our pos
-				s := yylex.Text()
 				lp := yylex.cast()
-				e := Error("Unrecognized")
-				if s == "\r" { // windows!
-					e = Error("CR")
-				}
-				lp.lexerErr = &LexParseErr{
-					Err: e,
-					Str: s,
-					Row: yylex.Line(),
-					Col: yylex.Column(),
-				}
+				lp.token.invalid = true
+				lp.lexerErrs = append(lp.lexerErrs, &LexParseErr{
+					Err:      Error("Unrecognized"),
+					Str:      yylex.Text(),
+					Row:      yylex.Line(),
+					Col:      yylex.Column(),
+					Filename: lp.filename,
+					Lexer:    true,
+				})
 				return ERROR
 			}
 		default:
@@ -0,0 +1,1554 @@
+// Code generated by goyacc -o parser.go parser.y. DO NOT EDIT.
+
+//line parser.y:2
+package lang
+
+import __yyfmt__ "fmt"
+
+//line parser.y:2
+
+import (
+	"aug/ast"
+	"aug/interfaces"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//line parser.y:14
+type yySymType struct {
+	yys  int
+	str  string
+	int  int
+	bool bool
+	strs []string
+
+	node  ast.Node
+	nodes []ast.Node
+
+	val interfaces.Value
+
+	row int
+	col int
+	end int
+}
+
+const STRING = 57346
+const IDENT = 57347
+const NUM = 57348
+const STR_VAR = 57349
+const INT_VAR = 57350
+const OPEN_PAREN = 57351
+const CLOSE_PAREN = 57352
+const OPEN_BRACKET = 57353
+const CLOSE_BRACKET = 57354
+const COMMA = 57355
+const SEMICOLON = 57356
+const COLON = 57357
+const PLUS = 57358
+const MINUS = 57359
+const MULTIPLY = 57360
+const DIVIDE = 57361
+const MOD = 57362
+const EQ = 57363
+const NEQ = 57364
+const LT = 57365
+const GT = 57366
+const LTE = 57367
+const GTE = 57368
+const STR_EQ = 57369
+const STR_NEQ = 57370
+const AND = 57371
+const OR = 57372
+const XOR = 57373
+const NOT = 57374
+const TRUE = 57375
+const FALSE = 57376
+const ASSIGN = 57377
+const FN_PRINT = 57378
+const FN_PRINTF = 57379
+const FN_WRITE = 57380
+const FN_LENGTH = 57381
+const FN_POSITION = 57382
+const FN_CONCATENATE = 57383
+const FN_SUBSTRING = 57384
+const FN_READINT = 57385
+const FN_READSTR = 57386
+const FN_READLN = 57387
+const FN_EOF = 57388
+const FN_STR = 57389
+const FN_INT = 57390
+const FN_ISINT = 57391
+const FN_CHR = 57392
+const FN_ORD = 57393
+const IF = 57394
+const THEN = 57395
+const ELSE = 57396
+const BEGIN = 57397
+const END = 57398
+const FOR = 57399
+const TO = 57400
+const DO = 57401
+const WHILE = 57402
+const REPEAT = 57403
+const UNTIL = 57404
+const BREAK = 57405
+const CONTINUE = 57406
+const EXIT = 57407
+const VAR = 57408
+const CONST = 57409
+const PRAGMA = 57410
+const FUNCTION = 57411
+const PROCEDURE = 57412
+const RETURN = 57413
+const ERROR = 57414
+const START_EXPR = 57415
+
+var yyToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
+	"STRING",
+	"IDENT",
+	"NUM",
+	"STR_VAR",
+	"INT_VAR",
+	"OPEN_PAREN",
+	"CLOSE_PAREN",
+	"OPEN_BRACKET",
+	"CLOSE_BRACKET",
+	"COMMA",
+	"SEMICOLON",
+	"COLON",
+	"PLUS",
+	"MINUS",
+	"MULTIPLY",
+	"DIVIDE",
+	"MOD",
+	"EQ",
+	"NEQ",
+	"LT",
+	"GT",
+	"LTE",
+	"GTE",
+	"STR_EQ",
+	"STR_NEQ",
+	"AND",
+	"OR",
+	"XOR",
+	"NOT",
+	"TRUE",
+	"FALSE",
+	"ASSIGN",
+	"FN_PRINT",
+	"FN_PRINTF",
+	"FN_WRITE",
+	"FN_LENGTH",
+	"FN_POSITION",
+	"FN_CONCATENATE",
+	"FN_SUBSTRING",
+	"FN_READINT",
+	"FN_READSTR",
+	"FN_READLN",
+	"FN_EOF",
+	"FN_STR",
+	"FN_INT",
+	"FN_ISINT",
+	"FN_CHR",
+	"FN_ORD",
+	"IF",
+	"THEN",
+	"ELSE",
+	"BEGIN",
+	"END",
+	"FOR",
+	"TO",
+	"DO",
+	"WHILE",
+	"REPEAT",
+	"UNTIL",
+	"BREAK",
+	"CONTINUE",
+	"EXIT",
+	"VAR",
+	"CONST",
+	"PRAGMA",
+	"FUNCTION",
+	"PROCEDURE",
+	"RETURN",
+	"ERROR",
+	"START_EXPR",
+}
+
+var yyStatenames = [...]string{}
+
+const yyEofCode = 1
+const yyErrCode = 2
+const yyInitialStackSize = 16
+
+//line parser.y:402
+
+// pos is a helper function used to track the position in the parser.
+func pos(y yyLexer, dollar yySymType) {
+	lp := cast(y)
+	lp.row = dollar.row
+	lp.col = dollar.col
+	return
+}
+
+// posSpan returns the span of the symbols matched by the parser, which is
+// where the node built from the rule is in the source code. It starts at the
+// first symbol and ends after the last one.
+func posSpan(y yyLexer, dollars []yySymType) ast.Pos {
+	first, last := dollars[1], dollars[len(dollars)-1]
+	end := last.end
+	if last.node != nil {
+		// non-terminals only carry the end of their first token
+		end = last.node.Position().End
+	}
+	return ast.Pos{
+		File: cast(y).filename,
+		Line: first.row + 1,
+		Col:  first.col + 1,
+		End:  end,
+	}
+}
+
+// cast is used to pull out the parser run-specific struct we store our AST in.
+// this is usually called in the parser.
+func cast(y yyLexer) *lexParseAST {
+	x := y.(interface{ cast() *lexParseAST })
+	return x.cast()
+}
+
+// postLast pulls out the "last token" and does a pos with that. This is a hack!
+func posLast(y yyLexer, dollars []yySymType) {
+	// pick the last token in the set matched by the parser
+	pos(y, dollars[len(dollars)-1]) // our pos
+}
+
+// cast is used to pull out the parser run-specific struct we store our AST in.
+// this is usually called in the lexer.
+func (yylex *Lexer) cast() *lexParseAST {
+	return yylex.parseResult.(*lexParseAST)
+}
+
+// pos is a helper function used to track the position in the lexer.
+func (yylex *Lexer) pos(lval *yySymType) {
+	lval.row = yylex.Line()
+	lval.col = yylex.Column()
+	lp := yylex.cast()
+	lp.offset += len(yylex.Text())
+	lval.end = lp.offset
+	lp.token = lexToken{str: yylex.Text(), row: lval.row, col: lval.col, trivia: lp.trivia}
+	lp.trivia = nil
+	//log.Printf("lexer: %d x %d", lval.row, lval.col)
+}
+
+// skip keeps track of the position of text which doesn't produce a token.
+func (yylex *Lexer) skip() {
+	yylex.cast().offset += len(yylex.Text())
+}
+
+// comment skips a comment, which is kept as trivia of the next token.
+func (yylex *Lexer) comment() {
+	lp := yylex.cast()
+	pos := ast.Pos{File: lp.filename, Line: yylex.Line() + 1, Col: yylex.Column() + 1}
+	yylex.skip()
+	pos.End = lp.offset
+	lp.trivia = append(lp.trivia, Comment{Text: yylex.Text(), Pos: pos})
+}
+
+// typeNamed returns the type of a declaration, or of an array of it, and
+// reports the names which are not a type.
+func typeNamed(y yyLexer, dollar yySymType, name string, array bool) ast.Type {
+	t, ok := ast.ParseType(name)
+	if ok && array {
+		t = ast.ArrayOf(t)
+	}
+	if !ok || t == ast.INVALID_TYPE {
+		lp := cast(y)
+		lp.parseErrs = append(lp.parseErrs, &LexParseErr{
+			Err:      "unknown type",
+			Str:      name,
+			Row:      dollar.row,
+			Col:      dollar.col,
+			Filename: lp.filename,
+		})
+		return ast.INVALID_TYPE
+	}
+	return t
+}
+
+// unescape decodes the escape sequences of a string literal, which are \",
+// \\, \n, \t, \r and \u{...} with the hexadecimal code point of a
+// character.
+func unescape(s string) (string, error) {
+	var b strings.Builder
+	for idx := 0; idx < len(s); idx++ {
+		if s[idx] != '\\' {
+			b.WriteByte(s[idx])
+			continue
+		}
+		idx++
+		if idx == len(s) {
+			return "", errors.New("invalid escape sequence at the end of the string")
+		}
+		switch s[idx] {
+		case '"', '\\':
+			b.WriteByte(s[idx])
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'u':
+			end := strings.IndexByte(s[idx:], '}')
+			if !strings.HasPrefix(s[idx:], "u{") || end < 0 {
+				return "", errors.New("invalid escape sequence, expected \\u{...}")
+			}
+			code, err := strconv.ParseUint(s[idx+2:idx+end], 16, 32)
+			if err != nil || end > 8 || !utf8.ValidRune(rune(code)) {
+				return "", errors.New("invalid code point: " + s[idx-1:idx+end+1])
+			}
+			b.WriteRune(rune(code))
+			idx += end
+		default:
+			return "", errors.New("invalid escape sequence: " + s[idx-1:idx+1])
+		}
+	}
+	return b.String(), nil
+}
+
+func init() {
+	// name the unexpected and the expected tokens in syntax errors
+	yyErrorVerbose = true
+}
+
+// Error is the error handler which gets called on a parsing error. The
+// parser then skips to the end of the statement and carries on, so every
+// syntax error is collected in lp.parseErrs.
+func (yylex *Lexer) Error(str string) {
+	lp := yylex.cast()
+	if str == "" {
+		return
+	}
+	if lp.token.invalid {
+		// The lexer generates ERROR tokens for the text it doesn't
+		// recognize, and already reported them.
+		return
+	}
+
+	e := &LexParseErr{
+		Err:      Error(str),
+		Str:      lp.token.str,
+		Row:      lp.token.row,
+		Col:      lp.token.col,
+		Filename: lp.filename,
+		EOF:      lp.eof,
+	}
+	if strings.Contains(str, "unexpected $end") {
+		// there is no offending token, the input ended after the last one
+		e.Err = Error(strings.Replace(str, "$end", "end of input", 1))
+		e.Str = ""
+	}
+	lp.parseErrs = append(lp.parseErrs, e)
+}
+
+//line yacctab:1
+var yyExca = [...]int8{
+	-1, 1,
+	1, -1,
+	-2, 0,
+	-1, 4,
+	1, 1,
+	-2, 0,
+}
+
+const yyPrivate = 57344
+
+const yyLast = 460
+
+var yyAct = [...]uint8{
+	90, 4, 38, 146, 6, 3, 36, 145, 199, 5,
+	71, 52, 12, 104, 8, 245, 11, 13, 54, 235,
+	88, 104, 104, 233, 89, 68, 69, 68, 69, 104,
+	213, 68, 69, 210, 70, 87, 7, 209, 187, 157,
+	101, 155, 108, 121, 68, 69, 74, 75, 105, 61,
+	63, 62, 68, 69, 237, 104, 158, 103, 111, 214,
+	113, 194, 114, 247, 246, 57, 107, 120, 46, 244,
+	58, 182, 218, 59, 60, 156, 48, 49, 50, 55,
+	56, 188, 64, 65, 66, 124, 54, 127, 128, 125,
+	126, 154, 135, 136, 137, 138, 139, 140, 141, 142,
+	143, 144, 129, 130, 131, 122, 123, 150, 151, 152,
+	147, 153, 52, 222, 84, 85, 86, 61, 63, 62,
+	134, 52, 170, 161, 162, 232, 197, 219, 134, 198,
+	68, 69, 229, 57, 228, 166, 46, 212, 58, 68,
+	69, 59, 60, 211, 48, 49, 50, 55, 56, 184,
+	64, 65, 66, 101, 185, 100, 189, 217, 191, 190,
+	193, 192, 179, 52, 196, 52, 195, 68, 69, 203,
+	204, 205, 165, 206, 202, 207, 181, 208, 74, 75,
+	68, 69, 180, 76, 81, 79, 77, 80, 78, 82,
+	83, 133, 134, 175, 164, 215, 176, 243, 173, 117,
+	54, 174, 216, 163, 171, 1, 240, 172, 225, 116,
+	226, 227, 115, 68, 69, 231, 230, 102, 68, 69,
+	52, 224, 106, 236, 68, 69, 68, 69, 68, 69,
+	99, 61, 63, 62, 98, 238, 160, 239, 97, 54,
+	241, 68, 69, 2, 52, 52, 52, 57, 223, 96,
+	46, 242, 58, 234, 221, 59, 60, 220, 48, 49,
+	50, 55, 56, 183, 64, 65, 66, 178, 68, 69,
+	61, 63, 62, 149, 68, 69, 54, 68, 69, 95,
+	201, 94, 93, 68, 69, 92, 57, 68, 69, 46,
+	91, 58, 119, 118, 59, 60, 159, 48, 49, 50,
+	55, 56, 169, 64, 65, 66, 112, 61, 63, 62,
+	39, 110, 109, 54, 67, 186, 200, 53, 51, 68,
+	69, 45, 44, 57, 43, 42, 46, 148, 58, 177,
+	168, 59, 60, 47, 48, 49, 50, 55, 56, 41,
+	64, 65, 66, 40, 61, 63, 62, 68, 69, 68,
+	69, 73, 72, 34, 15, 35, 14, 10, 0, 19,
+	57, 20, 0, 46, 0, 58, 167, 18, 59, 60,
+	0, 48, 49, 50, 55, 56, 0, 64, 65, 66,
+	0, 0, 9, 16, 17, 132, 68, 69, 0, 25,
+	26, 27, 28, 21, 22, 23, 24, 29, 30, 31,
+	32, 33, 15, 35, 14, 68, 69, 19, 0, 20,
+	0, 37, 0, 0, 0, 18, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 16, 17, 0, 0, 0, 0, 25, 26, 27,
+	28, 21, 22, 23, 24, 29, 30, 31, 32, 33,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 37,
+}
+
+var yyPact = [...]int16{
+	-68, -1000, -59, 350, 308, 309, 14, 5, -1000, 350,
+	-1000, 162, 96, -1000, -1000, -1000, -1000, -1000, 398, 350,
+	350, -1000, -1000, -1000, -1000, 281, 276, 273, 272, 270,
+	240, 229, 225, 221, -1000, 144, -1000, 208, 43, 41,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	213, -1000, -1000, -1000, 31, 307, 306, 350, 301, 350,
+	208, 203, 200, 190, 288, 287, 350, 29, 350, 350,
+	350, 14, 398, 398, 398, 398, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 398, 398, 398, -1000, 375, 179,
+	14, 350, 350, 350, 350, 350, 350, 350, 350, 350,
+	350, 350, 350, -1000, -1000, 271, 350, 350, 350, 76,
+	6, 22, 4, -3, 234, 350, 350, 199, 185, 163,
+	14, -1000, 5, 5, -1000, 30, 30, 96, 96, -1000,
+	-1000, -1000, -1000, -1000, 350, 356, 317, 289, 109, 194,
+	188, 183, 319, 257, 150, 172, 107, 166, -1000, 15,
+	253, 14, 137, 350, 33, 350, 81, 350, 81, 350,
+	-1, 156, 154, 116, 275, 275, 14, -1000, 350, 350,
+	350, -1000, 350, -1000, 350, -1000, 350, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 2, 14, -2, 132, 126, 14,
+	-24, 1, -1000, 14, 350, -1000, -1000, -1000, 350, 147,
+	59, -1000, 117, 247, 244, 100, 238, 211, 198, 350,
+	350, 122, 120, 81, 350, 14, 115, -32, 248, -36,
+	-1000, -1000, 350, -1000, -1000, -1000, 14, 14, -1000, -1000,
+	-1000, -5, -1000, -1000, -1000, -1000, 196, 81, 195, 13,
+	-1000, -1000, -1000, 8, -1000, 7, -1000, -1000,
+}
+
+var yyPgo = [...]int16{
+	0, 0, 36, 14, 357, 16, 12, 17, 353, 352,
+	351, 343, 339, 333, 325, 324, 322, 321, 2, 1,
+	318, 6, 317, 8, 316, 315, 243, 7, 3, 205,
+}
+
+var yyR1 = [...]int8{
+	0, 29, 29, 26, 26, 1, 1, 1, 2, 2,
+	3, 3, 4, 4, 4, 5, 5, 5, 6, 6,
+	6, 6, 7, 7, 7, 7, 7, 7, 7, 7,
+	7, 7, 7, 7, 7, 7, 7, 7, 7, 7,
+	7, 7, 7, 7, 7, 7, 8, 8, 8, 9,
+	9, 9, 9, 9, 9, 10, 10, 14, 14, 15,
+	16, 17, 17, 11, 11, 12, 12, 12, 12, 25,
+	25, 25, 25, 21, 21, 27, 27, 28, 28, 20,
+	20, 20, 20, 23, 23, 24, 24, 22, 22, 13,
+	13, 13, 13, 18, 18, 18, 18, 18, 18, 18,
+	18, 18, 18, 18, 18, 18, 18, 18, 18, 19,
+	19, 19,
+}
+
+var yyR2 = [...]int8{
+	0, 2, 2, 0, 4, 3, 3, 1, 3, 1,
+	2, 1, 3, 3, 1, 3, 3, 1, 3, 3,
+	3, 1, 1, 1, 1, 1, 2, 3, 3, 1,
+	1, 1, 1, 4, 6, 6, 8, 4, 6, 4,
+	6, 4, 6, 4, 4, 1, 1, 4, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 4, 6, 8,
+	4, 4, 5, 3, 6, 4, 4, 6, 4, 1,
+	3, 1, 3, 4, 4, 0, 1, 1, 3, 8,
+	9, 8, 9, 0, 1, 1, 3, 1, 2, 4,
+	4, 4, 6, 1, 1, 1, 1, 1, 1, 3,
+	4, 1, 1, 1, 1, 4, 1, 1, 1, 3,
+	3, 0,
+}
+
+var yyChk = [...]int16{
+	-1000, -29, -26, 73, -19, 68, -1, -2, -3, 32,
+	-4, -5, -6, -7, 6, 4, 33, 34, 17, 9,
+	11, 43, 44, 45, 46, 39, 40, 41, 42, 47,
+	48, 49, 50, 51, -8, 5, -21, 61, -18, 2,
+	-11, -12, -14, -15, -16, -17, 55, -13, 63, 64,
+	65, -20, -21, -22, 5, 66, 67, 52, 57, 60,
+	61, 36, 38, 37, 69, 70, 71, 5, 30, 31,
+	29, -1, -9, -10, 16, 17, 21, 24, 26, 23,
+	25, 22, 27, 28, 18, 19, 20, -5, -1, -28,
+	-1, 9, 9, 9, 9, 9, 9, 9, 9, 9,
+	11, 9, 9, 14, 14, -19, 9, 35, 11, 5,
+	5, -1, 5, -1, -19, 9, 9, 9, 5, 5,
+	-1, 14, -2, -2, -3, -5, -5, -6, -6, -7,
+	-7, -7, 10, 12, 13, -1, -1, -1, -1, -1,
+	-1, -1, -1, -1, -1, -27, -28, -27, 56, 2,
+	-1, -1, -1, 35, 15, 35, 53, 35, 59, 62,
+	2, -27, -27, 4, 9, 9, -1, 10, 13, 13,
+	13, 10, 13, 10, 13, 10, 13, 10, 10, 12,
+	10, 10, 56, 10, 12, -1, -25, 5, 48, -1,
+	-18, -1, -18, -1, 62, 10, 10, 10, 13, -23,
+	-24, 5, -23, -1, -1, -1, -1, -1, -1, 35,
+	35, 11, 11, 54, 58, -1, -28, 10, 13, 10,
+	10, 10, 13, 10, 10, 10, -1, -1, 12, 12,
+	-18, -1, 10, 55, 5, 55, -1, 59, -19, -19,
+	10, -18, 56, 2, 56, 2, 56, 56,
+}
+
+var yyDef = [...]int8{
+	3, -2, 111, 0, -2, 0, 2, 7, 9, 0,
+	11, 14, 17, 21, 22, 23, 24, 25, 0, 0,
+	0, 29, 30, 31, 32, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 45, 46, 48, 0, 0, 0,
+	93, 94, 95, 96, 97, 98, 111, 101, 102, 103,
+	104, 106, 107, 108, 0, 0, 0, 0, 0, 0,
+	111, 0, 0, 0, 0, 0, 87, 0, 0, 0,
+	0, 10, 0, 0, 0, 0, 49, 50, 51, 52,
+	53, 54, 55, 56, 0, 0, 0, 26, 0, 0,
+	77, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 75, 75, 109, 110, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 75, 75, 0, 0, 0,
+	88, 4, 5, 6, 8, 12, 13, 15, 16, 18,
+	19, 20, 27, 28, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 76, 0, 99, 0,
+	0, 63, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 83, 83, 78, 33, 0, 0,
+	0, 37, 0, 39, 0, 41, 0, 43, 44, 47,
+	73, 74, 100, 105, 0, 65, 66, 69, 71, 68,
+	57, 0, 60, 61, 0, 89, 90, 91, 0, 0,
+	84, 85, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 62, 0, 0, 0, 0,
+	34, 35, 0, 38, 40, 42, 64, 67, 70, 72,
+	58, 0, 92, 111, 86, 111, 0, 0, 0, 0,
+	36, 59, 79, 0, 81, 0, 80, 82,
+}
+
+var yyTok1 = [...]int8{
+	1,
+}
+
+var yyTok2 = [...]int8{
+	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
+	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73,
+}
+
+var yyTok3 = [...]int8{
+	0,
+}
+
+var yyErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
+//line yaccpar:1
+
+/*	parser for yacc output	*/
+
+var (
+	yyDebug        = 0
+	yyErrorVerbose = false
+)
+
+type yyLexer interface {
+	Lex(lval *yySymType) int
+	Error(s string)
+}
+
+type yyParser interface {
+	Parse(yyLexer) int
+	Lookahead() int
+}
+
+type yyParserImpl struct {
+	lval  yySymType
+	stack [yyInitialStackSize]yySymType
+	char  int
+}
+
+func (p *yyParserImpl) Lookahead() int {
+	return p.char
+}
+
+func yyNewParser() yyParser {
+	return &yyParserImpl{}
+}
+
+const yyFlag = -1000
+
+func yyTokname(c int) string {
+	if c >= 1 && c-1 < len(yyToknames) {
+		if yyToknames[c-1] != "" {
+			return yyToknames[c-1]
+		}
+	}
+	return __yyfmt__.Sprintf("tok-%v", c)
+}
+
+func yyStatname(s int) string {
+	if s >= 0 && s < len(yyStatenames) {
+		if yyStatenames[s] != "" {
+			return yyStatenames[s]
+		}
+	}
+	return __yyfmt__.Sprintf("state-%v", s)
+}
+
+func yyErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !yyErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range yyErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + yyTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(yyPact[state])
+	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if yyDef[state] == -2 {
+		i := 0
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; yyExca[i] >= 0; i += 2 {
+			tok := int(yyExca[i])
+			if tok < TOKSTART || yyExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if yyExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += yyTokname(tok)
+	}
+	return res
+}
+
+func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
+	if char <= 0 {
+		token = int(yyTok1[0])
+		goto out
+	}
+	if char < len(yyTok1) {
+		token = int(yyTok1[char])
+		goto out
+	}
+	if char >= yyPrivate {
+		if char < yyPrivate+len(yyTok2) {
+			token = int(yyTok2[char-yyPrivate])
+			goto out
+		}
+	}
+	for i := 0; i < len(yyTok3); i += 2 {
+		token = int(yyTok3[i+0])
+		if token == char {
+			token = int(yyTok3[i+1])
+			goto out
+		}
+	}
+
+out:
+	if token == 0 {
+		token = int(yyTok2[1]) /* unknown char */
+	}
+	if yyDebug >= 3 {
+		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
+	}
+	return char, token
+}
+
+func yyParse(yylex yyLexer) int {
+	return yyNewParser().Parse(yylex)
+}
+
+func (yyrcvr *yyParserImpl) Parse(yylex yyLexer) int {
+	var yyn int
+	var yyVAL yySymType
+	var yyDollar []yySymType
+	_ = yyDollar // silence set and not used
+	yyS := yyrcvr.stack[:]
+
+	Nerrs := 0   /* number of errors */
+	Errflag := 0 /* error recovery flag */
+	yystate := 0
+	yyrcvr.char = -1
+	yytoken := -1 // yyrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		yystate = -1
+		yyrcvr.char = -1
+		yytoken = -1
+	}()
+	yyp := -1
+	goto yystack
+
+ret0:
+	return 0
+
+ret1:
+	return 1
+
+yystack:
+	/* put a state and value onto the stack */
+	if yyDebug >= 4 {
+		__yyfmt__.Printf("char %v in %v\n", yyTokname(yytoken), yyStatname(yystate))
+	}
+
+	yyp++
+	if yyp >= len(yyS) {
+		nyys := make([]yySymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+	}
+	yyS[yyp] = yyVAL
+	yyS[yyp].yys = yystate
+
+yynewstate:
+	yyn = int(yyPact[yystate])
+	if yyn <= yyFlag {
+		goto yydefault /* simple state */
+	}
+	if yyrcvr.char < 0 {
+		yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
+	}
+	yyn += yytoken
+	if yyn < 0 || yyn >= yyLast {
+		goto yydefault
+	}
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
+		yyrcvr.char = -1
+		yytoken = -1
+		yyVAL = yyrcvr.lval
+		yystate = yyn
+		if Errflag > 0 {
+			Errflag--
+		}
+		goto yystack
+	}
+
+yydefault:
+	/* default state action */
+	yyn = int(yyDef[yystate])
+	if yyn == -2 {
+		if yyrcvr.char < 0 {
+			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
+		}
+
+		/* look through exception table */
+		xi := 0
+		for {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
+				break
+			}
+			xi += 2
+		}
+		for xi += 2; ; xi += 2 {
+			yyn = int(yyExca[xi+0])
+			if yyn < 0 || yyn == yytoken {
+				break
+			}
+		}
+		yyn = int(yyExca[xi+1])
+		if yyn < 0 {
+			goto ret0
+		}
+	}
+	if yyn == 0 {
+		/* error ... attempt to resume parsing */
+		switch Errflag {
+		case 0: /* brand new error */
+			yylex.Error(yyErrorMessage(yystate, yytoken))
+			Nerrs++
+			if yyDebug >= 1 {
+				__yyfmt__.Printf("%s", yyStatname(yystate))
+				__yyfmt__.Printf(" saw %s\n", yyTokname(yytoken))
+			}
+			fallthrough
+
+		case 1, 2: /* incompletely recovered error ... try again */
+			Errflag = 3
+
+			/* find a state where "error" is a legal shift action */
+			for yyp >= 0 {
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
+				if yyn >= 0 && yyn < yyLast {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
+						goto yystack
+					}
+				}
+
+				/* the current p has no shift on "error", pop stack */
+				if yyDebug >= 2 {
+					__yyfmt__.Printf("error recovery pops state %d\n", yyS[yyp].yys)
+				}
+				yyp--
+			}
+			/* there is no state on the stack with an error shift ... abort */
+			goto ret1
+
+		case 3: /* no shift yet; clobber input char */
+			if yyDebug >= 2 {
+				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yytoken))
+			}
+			if yytoken == yyEofCode {
+				goto ret1
+			}
+			yyrcvr.char = -1
+			yytoken = -1
+			goto yynewstate /* try again in the same state */
+		}
+	}
+
+	/* reduction by production yyn */
+	if yyDebug >= 2 {
+		__yyfmt__.Printf("reduce %v in:\n\t%v\n", yyn, yyStatname(yystate))
+	}
+
+	yynt := yyn
+	yypt := yyp
+	_ = yypt // guard against "declared and not used"
+
+	yyp -= int(yyR2[yyn])
+	// yyp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if yyp+1 >= len(yyS) {
+		nyys := make([]yySymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+	}
+	yyVAL = yyS[yyp+1]
+
+	/* consult goto table to find next state */
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
+	yyj := yyg + yyS[yyp].yys + 1
+
+	if yyj >= yyLast {
+		yystate = int(yyAct[yyg])
+	} else {
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
+		}
+	}
+	// dummy call; replaced with literal code
+	switch yynt {
+
+	case 1:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:69
+		{
+			posLast(yylex, yyDollar) // our pos
+			// store the AST in the struct that we previously passed in, the
+			// pragmas come first
+			lp := cast(yylex)
+			seq := yyDollar[2].node.(*ast.NodeSequence)
+			seq.Nodes = append(yyDollar[1].nodes, seq.Nodes...)
+			lp.ast = seq
+		}
+	case 2:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:78
+		{
+			// a single expression, as typed into the REPL
+			lp := cast(yylex)
+			lp.ast = yyDollar[2].node
+		}
+	case 3:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:85
+		{
+			yyVAL.nodes = nil
+		}
+	case 4:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:86
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, &ast.PragmaNode{Pos: posSpan(yylex, yyDollar[1:4]), Name: yyDollar[3].str})
+		}
+	case 5:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:95
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.LogicalExprNode{Pos: posSpan(yylex, yyDollar), Op: "or", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 6:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:99
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.LogicalExprNode{Pos: posSpan(yylex, yyDollar), Op: "xor", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 8:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:106
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.LogicalExprNode{Pos: posSpan(yylex, yyDollar), Op: "and", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 10:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:113
+		{ // takes the whole rest of the expression
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.UnaryOpNode{Pos: posSpan(yylex, yyDollar), Op: "!", Operand: yyDollar[2].node}
+		}
+	case 12:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:120
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.BoolExprNode{Pos: posSpan(yylex, yyDollar), Op: yyDollar[2].str, Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 13:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:124
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.BoolExprNode{Pos: posSpan(yylex, yyDollar), Op: yyDollar[2].str, Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 15:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:131
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "+", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 16:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:132
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "-", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 18:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:136
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "*", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 19:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:137
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "/", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 20:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:138
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "%", Left: yyDollar[1].node, Right: yyDollar[3].node}
+		}
+	case 22:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:142
+		{
+			posLast(yylex, yyDollar)
+			i, err := strconv.ParseInt(yyDollar[1].str, 10, 32)
+			if err != nil {
+				msg := Error("invalid integer")
+				if errors.Is(err, strconv.ErrRange) {
+					msg = "integer out of range"
+				}
+				lp := cast(yylex)
+				lp.parseErrs = append(lp.parseErrs, &LexParseErr{
+					Err:      msg,
+					Str:      yyDollar[1].str,
+					Row:      yyDollar[1].row,
+					Col:      yyDollar[1].col,
+					Filename: lp.filename,
+				})
+			} else {
+				yyVAL.node = &ast.NumLiteralNode{Pos: posSpan(yylex, yyDollar), Value: int(i)}
+			}
+		}
+	case 23:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:162
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.StringLiteral{Pos: posSpan(yylex, yyDollar), Value: yyDollar[1].str}
+		}
+	case 24:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:163
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.BoolLiteral{Pos: posSpan(yylex, yyDollar), Value: true}
+		}
+	case 25:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:164
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.BoolLiteral{Pos: posSpan(yylex, yyDollar), Value: false}
+		}
+	case 26:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:165
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.UnaryOpNode{Pos: posSpan(yylex, yyDollar), Op: "-", Operand: yyDollar[2].node}
+		}
+	case 27:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:166
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = yyDollar[2].node
+		}
+	case 28:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:167
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ArrayLiteral{Pos: posSpan(yylex, yyDollar), Elems: yyDollar[2].nodes}
+		}
+	case 29:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:171
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ReadIntNode{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 30:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:172
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ReadStr{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 31:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:176
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ReadLn{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 32:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:180
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.EOFNode{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 33:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:181
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.LengthNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node}
+		}
+	case 34:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:182
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.PositionNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node, Substr: yyDollar[5].node}
+		}
+	case 35:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:183
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.Concatenate{Pos: posSpan(yylex, yyDollar), Left: yyDollar[3].node, Right: yyDollar[5].node}
+		}
+	case 36:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:187
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.Substring{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node, Start: yyDollar[5].node, Length: yyDollar[7].node}
+		}
+	case 37:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:191
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.StrNode{Pos: posSpan(yylex, yyDollar), Value: yyDollar[3].node}
+		}
+	case 38:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:195
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.StrNode{Pos: posSpan(yylex, yyDollar), Value: yyDollar[3].node, Radix: yyDollar[5].node}
+		}
+	case 39:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:199
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IntNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node}
+		}
+	case 40:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:200
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IntNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node, Radix: yyDollar[5].node}
+		}
+	case 41:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:201
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IsIntNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node}
+		}
+	case 42:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:202
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IsIntNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node, Radix: yyDollar[5].node}
+		}
+	case 43:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:203
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ChrNode{Pos: posSpan(yylex, yyDollar), Code: yyDollar[3].node}
+		}
+	case 44:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:207
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.OrdNode{Pos: posSpan(yylex, yyDollar), Str: yyDollar[3].node}
+		}
+	case 46:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:212
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.VariableReferenceNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[1].str}
+		}
+	case 47:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:216
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IndexNode{Pos: posSpan(yylex, yyDollar), Array: &ast.VariableReferenceNode{Pos: posSpan(yylex, yyDollar[:2]), Name: yyDollar[1].str}, Index: yyDollar[3].node}
+		}
+	case 49:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:223
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 50:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:224
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 51:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:225
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 52:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:226
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 53:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:227
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 54:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:228
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 55:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:231
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 56:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:232
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.str = yyDollar[1].str
+		}
+	case 57:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:235
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IfStatNode{Pos: posSpan(yylex, yyDollar), Condition: yyDollar[2].node, ThenBranch: yyDollar[4].node}
+		}
+	case 58:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:239
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IfStatNode{Pos: posSpan(yylex, yyDollar), Condition: yyDollar[2].node, ThenBranch: yyDollar[4].node, ElseBranch: yyDollar[6].node}
+		}
+	case 59:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:245
+		{
+			yyVAL.node = &ast.ForStatNode{Pos: posSpan(yylex, yyDollar), Identifier: yyDollar[2].str, Initial: yyDollar[4].node, Final: yyDollar[6].node, Body: yyDollar[8].node}
+		}
+	case 60:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:250
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.WhileStatNode{Pos: posSpan(yylex, yyDollar), Condition: yyDollar[2].node, Body: yyDollar[4].node}
+		}
+	case 61:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:256
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.RepeatStatNode{Pos: posSpan(yylex, yyDollar), Body: yyDollar[2].node, Condition: yyDollar[4].node}
+		}
+	case 62:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:260
+		{
+			// the broken last statement is skipped up to the until
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.RepeatStatNode{Pos: posSpan(yylex, yyDollar), Body: yyDollar[2].node, Condition: yyDollar[5].node}
+		}
+	case 63:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:267
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.AssignStatNode{Pos: posSpan(yylex, yyDollar), Identifier: yyDollar[1].str, Value: yyDollar[3].node}
+		}
+	case 64:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:271
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.IndexAssignStatNode{Pos: posSpan(yylex, yyDollar), Identifier: yyDollar[1].str, Index: yyDollar[3].node, Value: yyDollar[6].node}
+		}
+	case 65:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:277
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.VarDeclNode{Pos: posSpan(yylex, yyDollar), Identifier: yyDollar[2].str, Value: yyDollar[4].node}
+		}
+	case 66:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:281
+		{
+			posLast(yylex, yyDollar)
+			pos := posSpan(yylex, yyDollar)
+			yyVAL.node = &ast.VarDeclNode{Pos: pos, Identifier: yyDollar[2].str, Type: ast.Type(yyDollar[4].int), Value: ast.Zero(pos, ast.Type(yyDollar[4].int))}
+		}
+	case 67:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:286
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.VarDeclNode{Pos: posSpan(yylex, yyDollar), Identifier: yyDollar[2].str, Type: ast.Type(yyDollar[4].int), Value: yyDollar[6].node}
+		}
+	case 68:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:290
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.VarDeclNode{Pos: posSpan(yylex, yyDollar), Identifier: yyDollar[2].str, Value: yyDollar[4].node, Const: true}
+		}
+	case 69:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:297
+		{
+			yyVAL.int = int(typeNamed(yylex, yyDollar[1], yyDollar[1].str, false))
+		}
+	case 70:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:298
+		{
+			yyVAL.int = int(typeNamed(yylex, yyDollar[1], yyDollar[1].str, true))
+		}
+	case 71:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:299
+		{
+			yyVAL.int = int(ast.INT_TYPE)
+		}
+	case 72:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:300
+		{
+			yyVAL.int = int(ast.ArrayOf(ast.INT_TYPE))
+		}
+	case 73:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:303
+		{
+			posLast(yylex, yyDollar)
+			if _, ok := ast.Builtins[yyDollar[1].str]; ok {
+				yyVAL.node = &ast.BuiltinNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[1].str, Args: yyDollar[3].nodes}
+			} else {
+				yyVAL.node = &ast.CallNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[1].str, Args: yyDollar[3].nodes}
+			}
+		}
+	case 74:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:311
+		{ // the library function, not the loop
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.BuiltinNode{Pos: posSpan(yylex, yyDollar), Name: "repeat", Args: yyDollar[3].nodes}
+		}
+	case 75:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:317
+		{
+			yyVAL.nodes = nil
+		}
+	case 77:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:321
+		{
+			yyVAL.nodes = []ast.Node{yyDollar[1].node}
+		}
+	case 78:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:322
+		{
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
+		}
+	case 79:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:325
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[2].str, Params: yyDollar[4].strs, Body: yyDollar[7].node}
+		}
+	case 80:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:329
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[2].str, Params: yyDollar[4].strs, Body: yyDollar[7].node}
+		}
+	case 81:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:333
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[2].str, Params: yyDollar[4].strs, Body: yyDollar[7].node, Procedure: true}
+		}
+	case 82:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:337
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: yyDollar[2].str, Params: yyDollar[4].strs, Body: yyDollar[7].node, Procedure: true}
+		}
+	case 83:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:343
+		{
+			yyVAL.strs = nil
+		}
+	case 85:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:347
+		{
+			yyVAL.strs = []string{yyDollar[1].str}
+		}
+	case 86:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:348
+		{
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[3].str)
+		}
+	case 87:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:351
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ReturnNode{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 88:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:352
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ReturnNode{Pos: posSpan(yylex, yyDollar), Value: yyDollar[2].node}
+		}
+	case 89:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:355
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.PrintStatNode{Pos: posSpan(yylex, yyDollar), Values: yyDollar[3].nodes, Newline: true}
+		}
+	case 90:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:356
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.PrintStatNode{Pos: posSpan(yylex, yyDollar), Values: yyDollar[3].nodes}
+		}
+	case 91:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:357
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.PrintfNode{Pos: posSpan(yylex, yyDollar), Format: yyDollar[3].str}
+		}
+	case 92:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:358
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.PrintfNode{Pos: posSpan(yylex, yyDollar), Format: yyDollar[3].str, Args: yyDollar[5].nodes}
+		}
+	case 99:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:367
+		{
+			yyVAL.node = &ast.BlockNode{Pos: posSpan(yylex, yyDollar), Statements: yyDollar[2].node.(*ast.NodeSequence).Nodes}
+		}
+	case 100:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:368
+		{
+			// the broken last statement is skipped up to the end
+			yyVAL.node = &ast.BlockNode{Pos: posSpan(yylex, yyDollar), Statements: yyDollar[2].node.(*ast.NodeSequence).Nodes}
+		}
+	case 102:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:373
+		{
+			yyVAL.node = &ast.BreakNode{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 103:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:374
+		{
+			yyVAL.node = &ast.ContinueNode{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 104:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:375
+		{
+			yyVAL.node = &ast.ExitNode{Pos: posSpan(yylex, yyDollar)}
+		}
+	case 105:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:376
+		{
+			posLast(yylex, yyDollar)
+			yyVAL.node = &ast.ExitNode{Pos: posSpan(yylex, yyDollar), Status: yyDollar[3].node}
+		}
+	case 109:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:385
+		{
+			seq := yyDollar[1].node.(*ast.NodeSequence)
+			// the sequence starts with its first statement and ends after the last ;
+			pos := seq.Pos
+			if len(seq.Nodes) == 0 {
+				pos = yyDollar[2].node.Position()
+			}
+			pos.End = yyDollar[3].end
+			yyVAL.node = &ast.NodeSequence{Pos: pos, Nodes: append(seq.Nodes, yyDollar[2].node)}
+		}
+	case 110:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:395
+		{
+			// skip the broken statement and carry on with the next one
+			yyVAL.node = yyDollar[1].node
+		}
+	case 111:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:399
+		{
+			yyVAL.node = &ast.NodeSequence{Nodes: []ast.Node{}}
+		}
+	}
+	goto yystack /* stack new state and value */
+}
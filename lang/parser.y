@@ -0,0 +1,570 @@
+%{
+package lang
+
+import (
+  "aug/interfaces"
+  "aug/ast"
+  "errors"
+  "strconv"
+  "strings"
+  "unicode/utf8"
+)
+%}
+
+%union {
+  str string
+  int int
+  bool bool
+  strs []string
+
+  node ast.Node
+  nodes []ast.Node
+
+  val interfaces.Value
+
+  row int
+	col int
+	end int
+}
+
+%token<str> STRING IDENT NUM STR_VAR INT_VAR
+%token OPEN_PAREN CLOSE_PAREN OPEN_BRACKET CLOSE_BRACKET
+%token COMMA SEMICOLON COLON
+%token PLUS MINUS MULTIPLY DIVIDE MOD
+%token<str> EQ NEQ LT GT LTE GTE
+%token<str> STR_EQ STR_NEQ
+%token AND OR XOR NOT
+%token<bool> TRUE FALSE
+
+%token ASSIGN
+%token FN_PRINT FN_PRINTF FN_WRITE
+%token FN_LENGTH FN_POSITION FN_CONCATENATE FN_SUBSTRING FN_READINT FN_READSTR FN_READLN FN_EOF
+%token FN_STR FN_INT FN_ISINT FN_CHR FN_ORD
+%token IF THEN ELSE
+%token BEGIN END
+%token FOR TO DO
+%token WHILE REPEAT UNTIL
+%token BREAK CONTINUE EXIT
+%token VAR CONST PRAGMA
+%token FUNCTION PROCEDURE RETURN
+%token ERROR
+%token START_EXPR // never produced by the lexer, see startLexer
+
+%type<node> expr t_expr f_expr rel_expr sum term factor primary
+%type<str> num_rel str_rel
+%type<node> assign_stat var_stat output_stat if_stat for_stat while_stat repeat_stat
+%type<node> simple_instr instr
+%type<node> func_decl call return_stat
+%type<strs> params param_list
+%type<int> type_name
+%type<nodes> pragmas
+%type<nodes> args arg_list
+
+
+
+%left PLUS MINUS 
+%left MULTIPLY DIVIDE MOD
+%%
+
+start: pragmas instr {
+		posLast(yylex, yyDollar) // our pos
+		// store the AST in the struct that we previously passed in, the
+		// pragmas come first
+		lp := cast(yylex)
+		seq := $2.(*ast.NodeSequence)
+		seq.Nodes = append($1, seq.Nodes...)
+		lp.ast = seq
+	}
+  | START_EXPR expr {
+    // a single expression, as typed into the REPL
+    lp := cast(yylex)
+    lp.ast = $2
+  }
+
+pragmas
+  : /* epsilon */ { $$ = nil }
+  | pragmas PRAGMA IDENT SEMICOLON {
+    $$ = append($1, &ast.PragmaNode{Pos: posSpan(yylex, yyDollar[1:4]), Name: $3})
+  }
+
+// Expressions are untyped in the grammar, the Checker infers the type of
+// each one and rejects operands of the wrong type. The levels bind from the
+// loosest to the tightest: or and xor, and, not, the relations, + and -, then
+// *, / and %.
+expr
+  : expr OR t_expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.LogicalExprNode{Pos: posSpan(yylex, yyDollar), Op: "or", Left: $1, Right: $3}
+  }
+  | expr XOR t_expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.LogicalExprNode{Pos: posSpan(yylex, yyDollar), Op: "xor", Left: $1, Right: $3}
+  }
+  | t_expr
+
+t_expr
+  : t_expr AND f_expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.LogicalExprNode{Pos: posSpan(yylex, yyDollar), Op: "and", Left: $1, Right: $3}
+  }
+  | f_expr
+
+f_expr
+  : NOT expr { // takes the whole rest of the expression
+    posLast(yylex, yyDollar); 
+    $$ = &ast.UnaryOpNode{Pos: posSpan(yylex, yyDollar), Op: "!", Operand: $2} 
+  }
+  | rel_expr
+
+rel_expr
+  : sum num_rel sum {
+    posLast(yylex, yyDollar);
+    $$ = &ast.BoolExprNode{Pos: posSpan(yylex, yyDollar), Op: $2, Left: $1, Right: $3}
+  }
+  | sum str_rel sum {
+    posLast(yylex, yyDollar);
+    $$ = &ast.BoolExprNode{Pos: posSpan(yylex, yyDollar), Op: $2, Left: $1, Right: $3}
+  }
+  | sum
+
+sum
+  : sum PLUS term { posLast(yylex, yyDollar); $$ = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "+", Left: $1, Right: $3}}
+  | sum MINUS term { posLast(yylex, yyDollar); $$ = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "-", Left: $1, Right: $3}}
+  | term
+
+term
+  : term MULTIPLY factor { posLast(yylex, yyDollar); $$ = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "*", Left: $1, Right: $3}}
+  | term DIVIDE factor { posLast(yylex, yyDollar); $$ = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "/", Left: $1, Right: $3}}
+  | term MOD factor { posLast(yylex, yyDollar); $$ = &ast.NumExprNode{Pos: posSpan(yylex, yyDollar), Op: "%", Left: $1, Right: $3}}
+  | factor
+
+factor
+  : NUM {
+    posLast(yylex, yyDollar); 
+    i, err := strconv.ParseInt($1, 10, 32)
+    if err != nil {
+        msg := Error("invalid integer")
+        if errors.Is(err, strconv.ErrRange) {
+            msg = "integer out of range"
+        }
+        lp := cast(yylex)
+        lp.parseErrs = append(lp.parseErrs, &LexParseErr{
+            Err: msg,
+            Str: $1,
+            Row: yyDollar[1].row,
+            Col: yyDollar[1].col,
+            Filename: lp.filename,
+        })
+    } else {
+        $$ = &ast.NumLiteralNode{Pos: posSpan(yylex, yyDollar), Value: int(i)}
+    }
+  }
+  | STRING { posLast(yylex, yyDollar); $$ = &ast.StringLiteral{Pos: posSpan(yylex, yyDollar), Value: $1} }
+  | TRUE { posLast(yylex, yyDollar); $$ = &ast.BoolLiteral{Pos: posSpan(yylex, yyDollar), Value: true} }
+  | FALSE { posLast(yylex, yyDollar); $$ = &ast.BoolLiteral{Pos: posSpan(yylex, yyDollar), Value: false} }
+  | MINUS sum { posLast(yylex, yyDollar); $$ = &ast.UnaryOpNode{Pos: posSpan(yylex, yyDollar), Op: "-", Operand: $2} }
+  | OPEN_PAREN expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = $2 }
+  | OPEN_BRACKET arg_list CLOSE_BRACKET {
+    posLast(yylex, yyDollar);
+    $$ = &ast.ArrayLiteral{Pos: posSpan(yylex, yyDollar), Elems: $2}
+  }
+  | FN_READINT { posLast(yylex, yyDollar); $$ = &ast.ReadIntNode{Pos: posSpan(yylex, yyDollar)} }
+  | FN_READSTR {
+    posLast(yylex, yyDollar);
+    $$ = &ast.ReadStr{Pos: posSpan(yylex, yyDollar)}
+  }
+  | FN_READLN {
+    posLast(yylex, yyDollar);
+    $$ = &ast.ReadLn{Pos: posSpan(yylex, yyDollar)}
+  }
+  | FN_EOF { posLast(yylex, yyDollar); $$ = &ast.EOFNode{Pos: posSpan(yylex, yyDollar)} }
+  | FN_LENGTH OPEN_PAREN expr CLOSE_PAREN  { posLast(yylex, yyDollar); $$ = &ast.LengthNode{Pos: posSpan(yylex, yyDollar), Str: $3} }
+  | FN_POSITION OPEN_PAREN expr COMMA expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.PositionNode{Pos: posSpan(yylex, yyDollar), Str: $3, Substr: $5} }
+  | FN_CONCATENATE OPEN_PAREN expr COMMA expr CLOSE_PAREN {
+    posLast(yylex, yyDollar);
+    $$ = &ast.Concatenate{Pos: posSpan(yylex, yyDollar), Left: $3, Right: $5}
+  }
+  | FN_SUBSTRING OPEN_PAREN expr COMMA expr COMMA expr CLOSE_PAREN  {
+    posLast(yylex, yyDollar);
+    $$ = &ast.Substring{Pos: posSpan(yylex, yyDollar), Str: $3, Start: $5, Length: $7} 
+  }
+  | FN_STR OPEN_PAREN expr CLOSE_PAREN {
+    posLast(yylex, yyDollar);
+    $$ = &ast.StrNode{Pos: posSpan(yylex, yyDollar), Value: $3}
+  }
+  | FN_STR OPEN_PAREN expr COMMA expr CLOSE_PAREN {
+    posLast(yylex, yyDollar);
+    $$ = &ast.StrNode{Pos: posSpan(yylex, yyDollar), Value: $3, Radix: $5}
+  }
+  | FN_INT OPEN_PAREN expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.IntNode{Pos: posSpan(yylex, yyDollar), Str: $3} }
+  | FN_INT OPEN_PAREN expr COMMA expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.IntNode{Pos: posSpan(yylex, yyDollar), Str: $3, Radix: $5} }
+  | FN_ISINT OPEN_PAREN expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.IsIntNode{Pos: posSpan(yylex, yyDollar), Str: $3} }
+  | FN_ISINT OPEN_PAREN expr COMMA expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.IsIntNode{Pos: posSpan(yylex, yyDollar), Str: $3, Radix: $5} }
+  | FN_CHR OPEN_PAREN expr CLOSE_PAREN {
+    posLast(yylex, yyDollar);
+    $$ = &ast.ChrNode{Pos: posSpan(yylex, yyDollar), Code: $3}
+  }
+  | FN_ORD OPEN_PAREN expr CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.OrdNode{Pos: posSpan(yylex, yyDollar), Str: $3} }
+  | primary
+
+// variables, their elements and calls, whatever their type
+primary
+  : IDENT {
+    posLast(yylex, yyDollar);
+    $$ = &ast.VariableReferenceNode{Pos: posSpan(yylex, yyDollar), Name: $1}
+  }
+  | IDENT OPEN_BRACKET expr CLOSE_BRACKET {
+    posLast(yylex, yyDollar);
+    $$ = &ast.IndexNode{Pos: posSpan(yylex, yyDollar), Array: &ast.VariableReferenceNode{Pos: posSpan(yylex, yyDollar[:2]), Name: $1}, Index: $3}
+  }
+  | call
+
+num_rel
+  : EQ { posLast(yylex, yyDollar); $$ = $1 }
+  | GT { posLast(yylex, yyDollar); $$ = $1 }
+  | GTE { posLast(yylex, yyDollar); $$ = $1 }
+  | LT { posLast(yylex, yyDollar); $$ = $1 }
+  | LTE { posLast(yylex, yyDollar); $$ = $1 }
+  | NEQ { posLast(yylex, yyDollar); $$ = $1 }
+
+str_rel
+  : STR_EQ { posLast(yylex, yyDollar); $$ = $1 }
+  | STR_NEQ { posLast(yylex, yyDollar); $$ = $1 }
+
+if_stat
+  : IF expr THEN simple_instr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.IfStatNode{Pos: posSpan(yylex, yyDollar), Condition: $2, ThenBranch: $4}
+  }
+  | IF expr THEN simple_instr ELSE simple_instr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.IfStatNode{Pos: posSpan(yylex, yyDollar), Condition: $2, ThenBranch: $4, ElseBranch: $6}
+  }
+
+for_stat
+  : FOR IDENT ASSIGN expr TO expr DO simple_instr {
+    $$ = &ast.ForStatNode{Pos: posSpan(yylex, yyDollar), Identifier: $2, Initial: $4, Final: $6, Body: $8}
+  }
+
+while_stat
+  : WHILE expr DO simple_instr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.WhileStatNode{Pos: posSpan(yylex, yyDollar), Condition: $2, Body: $4}
+  }
+
+repeat_stat
+  : REPEAT instr UNTIL expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.RepeatStatNode{Pos: posSpan(yylex, yyDollar), Body: $2, Condition: $4}
+  }
+  | REPEAT instr error UNTIL expr {
+    // the broken last statement is skipped up to the until
+    posLast(yylex, yyDollar);
+    $$ = &ast.RepeatStatNode{Pos: posSpan(yylex, yyDollar), Body: $2, Condition: $5}
+  }
+
+assign_stat
+  : IDENT ASSIGN expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.AssignStatNode{Pos: posSpan(yylex, yyDollar), Identifier: $1, Value: $3}
+  }
+  | IDENT OPEN_BRACKET expr CLOSE_BRACKET ASSIGN expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.IndexAssignStatNode{Pos: posSpan(yylex, yyDollar), Identifier: $1, Index: $3, Value: $6}
+  }
+
+var_stat
+  : VAR IDENT ASSIGN expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.VarDeclNode{Pos: posSpan(yylex, yyDollar), Identifier: $2, Value: $4}
+  }
+  | VAR IDENT COLON type_name {
+    posLast(yylex, yyDollar);
+    pos := posSpan(yylex, yyDollar)
+    $$ = &ast.VarDeclNode{Pos: pos, Identifier: $2, Type: ast.Type($4), Value: ast.Zero(pos, ast.Type($4))}
+  }
+  | VAR IDENT COLON type_name ASSIGN expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.VarDeclNode{Pos: posSpan(yylex, yyDollar), Identifier: $2, Type: ast.Type($4), Value: $6}
+  }
+  | CONST IDENT ASSIGN expr {
+    posLast(yylex, yyDollar);
+    $$ = &ast.VarDeclNode{Pos: posSpan(yylex, yyDollar), Identifier: $2, Value: $4, Const: true}
+  }
+
+// the names of the types are not keywords, so they may still name variables
+type_name
+  : IDENT { $$ = int(typeNamed(yylex, yyDollar[1], $1, false)) }
+  | IDENT OPEN_BRACKET CLOSE_BRACKET { $$ = int(typeNamed(yylex, yyDollar[1], $1, true)) }
+  | FN_INT { $$ = int(ast.INT_TYPE) } // int is also the conversion built-in
+  | FN_INT OPEN_BRACKET CLOSE_BRACKET { $$ = int(ast.ArrayOf(ast.INT_TYPE)) }
+
+call
+  : IDENT OPEN_PAREN args CLOSE_PAREN {
+    posLast(yylex, yyDollar);
+    if _, ok := ast.Builtins[$1]; ok {
+      $$ = &ast.BuiltinNode{Pos: posSpan(yylex, yyDollar), Name: $1, Args: $3}
+    } else {
+      $$ = &ast.CallNode{Pos: posSpan(yylex, yyDollar), Name: $1, Args: $3}
+    }
+  }
+  | REPEAT OPEN_PAREN args CLOSE_PAREN { // the library function, not the loop
+    posLast(yylex, yyDollar);
+    $$ = &ast.BuiltinNode{Pos: posSpan(yylex, yyDollar), Name: "repeat", Args: $3}
+  }
+
+args
+  : /* epsilon */ { $$ = nil }
+  | arg_list
+
+arg_list
+  : expr { $$ = []ast.Node{$1} }
+  | arg_list COMMA expr { $$ = append($1, $3) }
+
+func_decl
+  : FUNCTION IDENT OPEN_PAREN params CLOSE_PAREN BEGIN instr END {
+    posLast(yylex, yyDollar);
+    $$ = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: $2, Params: $4, Body: $7}
+  }
+  | FUNCTION IDENT OPEN_PAREN params CLOSE_PAREN BEGIN instr error END {
+    posLast(yylex, yyDollar);
+    $$ = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: $2, Params: $4, Body: $7}
+  }
+  | PROCEDURE IDENT OPEN_PAREN params CLOSE_PAREN BEGIN instr END {
+    posLast(yylex, yyDollar);
+    $$ = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: $2, Params: $4, Body: $7, Procedure: true}
+  }
+  | PROCEDURE IDENT OPEN_PAREN params CLOSE_PAREN BEGIN instr error END {
+    posLast(yylex, yyDollar);
+    $$ = &ast.FunctionDeclNode{Pos: posSpan(yylex, yyDollar), Name: $2, Params: $4, Body: $7, Procedure: true}
+  }
+
+params
+  : /* epsilon */ { $$ = nil }
+  | param_list
+
+param_list
+  : IDENT { $$ = []string{$1} }
+  | param_list COMMA IDENT { $$ = append($1, $3) }
+
+return_stat
+  : RETURN { posLast(yylex, yyDollar); $$ = &ast.ReturnNode{Pos: posSpan(yylex, yyDollar)} }
+  | RETURN expr { posLast(yylex, yyDollar); $$ = &ast.ReturnNode{Pos: posSpan(yylex, yyDollar), Value: $2} }
+
+output_stat
+  : FN_PRINT OPEN_PAREN args CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.PrintStatNode{Pos: posSpan(yylex, yyDollar), Values: $3, Newline: true} }
+  | FN_WRITE OPEN_PAREN args CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.PrintStatNode{Pos: posSpan(yylex, yyDollar), Values: $3} }
+  | FN_PRINTF OPEN_PAREN STRING CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.PrintfNode{Pos: posSpan(yylex, yyDollar), Format: $3} }
+  | FN_PRINTF OPEN_PAREN STRING COMMA arg_list CLOSE_PAREN { posLast(yylex, yyDollar); $$ = &ast.PrintfNode{Pos: posSpan(yylex, yyDollar), Format: $3, Args: $5} }
+
+simple_instr 
+  : assign_stat  
+  | var_stat
+  | if_stat 
+  | for_stat
+  | while_stat
+  | repeat_stat
+  | BEGIN instr END { $$ = &ast.BlockNode{Pos: posSpan(yylex, yyDollar), Statements: $2.(*ast.NodeSequence).Nodes} }
+  | BEGIN instr error END {
+    // the broken last statement is skipped up to the end
+    $$ = &ast.BlockNode{Pos: posSpan(yylex, yyDollar), Statements: $2.(*ast.NodeSequence).Nodes}
+  }
+  | output_stat
+  | BREAK { $$ = &ast.BreakNode{Pos: posSpan(yylex, yyDollar)} }
+  | CONTINUE { $$ = &ast.ContinueNode{Pos: posSpan(yylex, yyDollar)} }
+  | EXIT { $$ = &ast.ExitNode{Pos: posSpan(yylex, yyDollar)} }
+  | EXIT OPEN_PAREN expr CLOSE_PAREN {
+    posLast(yylex, yyDollar);
+    $$ = &ast.ExitNode{Pos: posSpan(yylex, yyDollar), Status: $3}
+  }
+  | func_decl
+  | call
+  | return_stat
+
+instr
+  : instr simple_instr SEMICOLON {
+    seq := $1.(*ast.NodeSequence)
+    // the sequence starts with its first statement and ends after the last ;
+    pos := seq.Pos
+    if len(seq.Nodes) == 0 {
+      pos = $2.Position()
+    }
+    pos.End = yyDollar[3].end
+    $$ = &ast.NodeSequence{Pos: pos, Nodes: append(seq.Nodes, $2)}
+  }
+  | instr error SEMICOLON {
+    // skip the broken statement and carry on with the next one
+    $$ = $1
+  }
+  | /* epsilon */ { $$ = &ast.NodeSequence{Nodes: []ast.Node{}} }
+
+
+%%
+
+// pos is a helper function used to track the position in the parser.
+func pos(y yyLexer, dollar yySymType) {
+	lp := cast(y)
+	lp.row = dollar.row
+	lp.col = dollar.col
+	return
+}
+
+// posSpan returns the span of the symbols matched by the parser, which is
+// where the node built from the rule is in the source code. It starts at the
+// first symbol and ends after the last one.
+func posSpan(y yyLexer, dollars []yySymType) ast.Pos {
+	first, last := dollars[1], dollars[len(dollars)-1]
+	end := last.end
+	if last.node != nil {
+		// non-terminals only carry the end of their first token
+		end = last.node.Position().End
+	}
+	return ast.Pos{
+		File: cast(y).filename,
+		Line: first.row + 1,
+		Col:  first.col + 1,
+		End:  end,
+	}
+}
+
+// cast is used to pull out the parser run-specific struct we store our AST in.
+// this is usually called in the parser.
+func cast(y yyLexer) *lexParseAST {
+	x := y.(interface{ cast() *lexParseAST })
+	return x.cast()
+}
+
+// postLast pulls out the "last token" and does a pos with that. This is a hack!
+func posLast(y yyLexer, dollars []yySymType) {
+	// pick the last token in the set matched by the parser
+	pos(y, dollars[len(dollars)-1]) // our pos
+}
+
+// cast is used to pull out the parser run-specific struct we store our AST in.
+// this is usually called in the lexer.
+func (yylex *Lexer) cast() *lexParseAST {
+	return yylex.parseResult.(*lexParseAST)
+}
+
+// pos is a helper function used to track the position in the lexer.
+func (yylex *Lexer) pos(lval *yySymType) {
+	lval.row = yylex.Line()
+	lval.col = yylex.Column()
+	lp := yylex.cast()
+	lp.offset += len(yylex.Text())
+	lval.end = lp.offset
+	lp.token = lexToken{str: yylex.Text(), row: lval.row, col: lval.col, trivia: lp.trivia}
+	lp.trivia = nil
+	//log.Printf("lexer: %d x %d", lval.row, lval.col)
+}
+
+// skip keeps track of the position of text which doesn't produce a token.
+func (yylex *Lexer) skip() {
+	yylex.cast().offset += len(yylex.Text())
+}
+
+// comment skips a comment, which is kept as trivia of the next token.
+func (yylex *Lexer) comment() {
+	lp := yylex.cast()
+	pos := ast.Pos{File: lp.filename, Line: yylex.Line() + 1, Col: yylex.Column() + 1}
+	yylex.skip()
+	pos.End = lp.offset
+	lp.trivia = append(lp.trivia, Comment{Text: yylex.Text(), Pos: pos})
+}
+
+// typeNamed returns the type of a declaration, or of an array of it, and
+// reports the names which are not a type.
+func typeNamed(y yyLexer, dollar yySymType, name string, array bool) ast.Type {
+	t, ok := ast.ParseType(name)
+	if ok && array {
+		t = ast.ArrayOf(t)
+	}
+	if !ok || t == ast.INVALID_TYPE {
+		lp := cast(y)
+		lp.parseErrs = append(lp.parseErrs, &LexParseErr{
+			Err:      "unknown type",
+			Str:      name,
+			Row:      dollar.row,
+			Col:      dollar.col,
+			Filename: lp.filename,
+		})
+		return ast.INVALID_TYPE
+	}
+	return t
+}
+
+// unescape decodes the escape sequences of a string literal, which are \",
+// \\, \n, \t, \r and \u{...} with the hexadecimal code point of a
+// character.
+func unescape(s string) (string, error) {
+	var b strings.Builder
+	for idx := 0; idx < len(s); idx++ {
+		if s[idx] != '\\' {
+			b.WriteByte(s[idx])
+			continue
+		}
+		idx++
+		if idx == len(s) {
+			return "", errors.New("invalid escape sequence at the end of the string")
+		}
+		switch s[idx] {
+		case '"', '\\':
+			b.WriteByte(s[idx])
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'u':
+			end := strings.IndexByte(s[idx:], '}')
+			if !strings.HasPrefix(s[idx:], "u{") || end < 0 {
+				return "", errors.New("invalid escape sequence, expected \\u{...}")
+			}
+			code, err := strconv.ParseUint(s[idx+2:idx+end], 16, 32)
+			if err != nil || end > 8 || !utf8.ValidRune(rune(code)) {
+				return "", errors.New("invalid code point: " + s[idx-1:idx+end+1])
+			}
+			b.WriteRune(rune(code))
+			idx += end
+		default:
+			return "", errors.New("invalid escape sequence: " + s[idx-1:idx+1])
+		}
+	}
+	return b.String(), nil
+}
+
+func init() {
+	// name the unexpected and the expected tokens in syntax errors
+	yyErrorVerbose = true
+}
+
+// Error is the error handler which gets called on a parsing error. The
+// parser then skips to the end of the statement and carries on, so every
+// syntax error is collected in lp.parseErrs.
+func (yylex *Lexer) Error(str string) {
+	lp := yylex.cast()
+	if str == "" {
+		return
+	}
+	if lp.token.invalid {
+		// The lexer generates ERROR tokens for the text it doesn't
+		// recognize, and already reported them.
+		return
+	}
+
+	e := &LexParseErr{
+		Err:      Error(str),
+		Str:      lp.token.str,
+		Row:      lp.token.row,
+		Col:      lp.token.col,
+		Filename: lp.filename,
+		EOF:      lp.eof,
+	}
+	if strings.Contains(str, "unexpected $end") {
+		// there is no offending token, the input ended after the last one
+		e.Err = Error(strings.Replace(str, "$end", "end of input", 1))
+		e.Str = ""
+	}
+	lp.parseErrs = append(lp.parseErrs, e)
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// errInterrupted is returned by readLine when the user presses Ctrl-C.
+var errInterrupted = errors.New("interrupted")
+
+// lineReader reads the lines typed into the REPL.
+type lineReader interface {
+	readLine(prompt string) (string, error)
+	addHistory(line string)
+}
+
+// plainReader reads lines from a file or a pipe, without prompts.
+type plainReader struct {
+	in *bufio.Reader
+}
+
+func (r *plainReader) readLine(prompt string) (string, error) {
+	line, err := r.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (r *plainReader) addHistory(line string) {}
+
+// lineEditor reads lines from a terminal in raw mode. It supports moving the
+// cursor within the line and browsing the previous lines with the arrow keys.
+type lineEditor struct {
+	in      *os.File
+	keys    *bufio.Reader
+	out     io.Writer
+	history []string
+}
+
+func newLineEditor(in *os.File, out io.Writer) *lineEditor {
+	return &lineEditor{in: in, keys: bufio.NewReader(in), out: out}
+}
+
+func (e *lineEditor) addHistory(line string) {
+	if line == "" || (len(e.history) > 0 && e.history[len(e.history)-1] == line) {
+		return
+	}
+	e.history = append(e.history, line)
+}
+
+// readLine shows the prompt and lets the user edit a line. The terminal is
+// only in raw mode while the line is read, so the program run in between
+// reads its input as usual.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	restore, err := enableRawMode(int(e.in.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	var line []rune
+	cursor := 0
+	entry := len(e.history) // the history entry shown, len(e.history) is the new line
+	pending := ""           // the new line, kept while browsing the history
+
+	refresh := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K\r", prompt, string(line))
+		if column := utf8.RuneCountInString(prompt) + cursor; column > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dC", column)
+		}
+	}
+	show := func(idx int) {
+		if idx == len(e.history) {
+			line = []rune(pending)
+		} else {
+			line = []rune(e.history[idx])
+		}
+		entry, cursor = idx, len(line)
+	}
+
+	refresh()
+	for {
+		key, _, err := e.keys.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch key {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupted
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+			}
+		case 127, 8: // Backspace
+			if cursor > 0 {
+				line = append(line[:cursor-1], line[cursor:]...)
+				cursor--
+			}
+		case 1: // Ctrl-A
+			cursor = 0
+		case 5: // Ctrl-E
+			cursor = len(line)
+		case 11: // Ctrl-K
+			line = line[:cursor]
+		case 21: // Ctrl-U
+			line = line[cursor:]
+			cursor = 0
+		case 0x1b: // escape sequences of the arrow and editing keys
+			if next, _, _ := e.keys.ReadRune(); next != '[' && next != 'O' {
+				continue
+			}
+			// the sequence ends with its final byte, keys like Delete end
+			// with ~ and are told apart by the number before it
+			var seq []rune
+			for {
+				r, _, err := e.keys.ReadRune()
+				if err != nil {
+					return "", err
+				}
+				seq = append(seq, r)
+				if r >= '@' && r <= '~' {
+					break
+				}
+			}
+			code := seq[len(seq)-1]
+			if code == '~' {
+				code = seq[0]
+			}
+			switch code {
+			case 'A': // Up
+				if entry > 0 {
+					if entry == len(e.history) {
+						pending = string(line)
+					}
+					show(entry - 1)
+				}
+			case 'B': // Down
+				if entry < len(e.history) {
+					show(entry + 1)
+				}
+			case 'C': // Right
+				if cursor < len(line) {
+					cursor++
+				}
+			case 'D': // Left
+				if cursor > 0 {
+					cursor--
+				}
+			case 'H', '1': // Home
+				cursor = 0
+			case 'F', '4': // End
+				cursor = len(line)
+			case '3': // Delete
+				if cursor < len(line) {
+					line = append(line[:cursor], line[cursor+1:]...)
+				}
+			}
+		default:
+			if key < ' ' {
+				continue // other control keys
+			}
+			line = append(line[:cursor], append([]rune{key}, line[cursor:]...)...)
+			cursor++
+		}
+		refresh()
+	}
+}
@@ -2,92 +2,137 @@ package main
 
 import (
 	"aug/ast"
-	"aug/interfaces"
+	"aug/emit"
+	"aug/lang"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"os"
 )
 
-type Error string
-
-// Error fulfills the error interface of this type.
-func (e Error) Error() string { return string(e) }
-
-// LexParseErr is a permanent failure error to notify about borkage.
-type LexParseErr struct {
-	Err Error
-	Str string
-	Row int // this is zero-indexed (the first line is 0)
-	Col int // this is zero-indexed (the first char is 0)
-
-	// Filename is the file that this error occurred in. If this is unknown,
-	// then it will be empty. This is not set when run by the basic LexParse
-	// function.
-	Filename string
-}
-
-// Error displays this error with all the relevant state information.
-func (e *LexParseErr) Error() string {
-	return fmt.Sprintf("%s: `%s` @%d:%d", e.Err, e.Str, e.Row+1, e.Col+1)
+func main() {
+	os.Exit(run())
 }
 
-type lexParseAST struct {
-	ast ast.Node
-	row int
-	col int
-
-	lexerErr error // from lexer
-	parseErr error // from Error(e string)
+// run is the compiler command, it returns the exit status: the one the
+// program chose with exit, 1 when it could not be read or run, or 2 for a
+// wrong command line.
+func run() int {
+	engine := flag.String("engine", "tree", "run the program with the AST interpreter (tree) or the bytecode virtual machine (vm)")
+	interactive := flag.Bool("i", false, "start the REPL, which is the default when no file is given and stdin is a terminal")
+	emitLang := flag.String("emit", "", "translate the program instead of running it, go writes a standalone main.go")
+	output := flag.String("o", "", "file to write the translated program to, instead of stdout")
+	overflowName := flag.String("overflow", "trap", "what arithmetic does with results beyond 32 bits, stop with an error (trap) or wrap around (wrap)")
+	strict := flag.Bool("strict", false, "require every variable to be declared with var or const before it is assigned")
+	flag.Parse()
+
+	overflow, err := ast.ParseOverflow(*overflowName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
 
-	variablesTable *interfaces.VariablesTable
-}
+	if *interactive || (flag.NArg() == 0 && isTerminal(int(os.Stdin.Fd()))) {
+		if *engine != "tree" {
+			fmt.Fprintln(os.Stderr, "The REPL only runs with the tree engine.")
+			return 2
+		}
+		return runREPL(os.Stdin, os.Stdout, overflow, *strict)
+	}
 
-func main() {
 	var input io.Reader
-
-	variablesTable := interfaces.MakeVariablesTable()
-	lp := &lexParseAST{variablesTable: &variablesTable}
+	var filename string
 
 	// Check if a filename argument is provided
-	if len(os.Args) > 1 {
+	if flag.NArg() > 0 {
 		// Open the file for reading
-		file, err := os.Open(os.Args[1])
+		file, err := os.Open(flag.Arg(0))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error opening file: %s\n", err)
-			os.Exit(1)
+			return 1
 		}
 		defer file.Close()
 		input = file
+		filename = flag.Arg(0)
 	} else {
 		input = os.Stdin
 	}
-	lexer := NewLexerWithInit(input, func(y *Lexer) { y.parseResult = lp })
-
-	yyParse(lexer) // writes the result to lp.ast
 
-	if e := lp.parseErr; e != nil {
-		fmt.Println("Parser Error", e)
-	}
-	if e := lp.lexerErr; e != nil {
-		fmt.Println("Lexer Error", e)
+	program, errs := lang.ParseFile(input, filename)
+	if reportSyntax(os.Stdout, errs) {
+		return 1
 	}
 
-	var err error
-	// Interpret the AST.
-	interpreter := &ast.Interpreter{VariablesTable: lp.variablesTable}
-	if lp.ast != nil {
-		_, err = lp.ast.Interpret(interpreter)
-		if err != nil {
+	if program == nil {
+		fmt.Println("No AST was generated by the parser.")
+		return 1
+	}
+	if *emitLang != "" {
+		// Translate the AST instead of running it.
+		checker := ast.NewChecker()
+		checker.Strict = *strict
+		if errs := checker.CheckProgram(program); len(errs) > 0 {
+			reportTypes(os.Stdout, errs)
+			return 1
+		}
+		if err := translate(program, *emitLang, *output, overflow); err != nil {
 			fmt.Println(err)
+			return 1
 		}
-	} else {
-		fmt.Println("No AST was generated by the parser.")
+		return 0
 	}
 
+	// The program is type checked before any of it runs, the runtime
+	// errors have always been shown on stdout.
+	err = lang.Run(context.Background(), program, lang.Options{
+		Engine:   *engine,
+		Overflow: overflow,
+		Strict:   *strict,
+		Stdin:    os.Stdin,
+		Stdout:   os.Stdout,
+		Stderr:   os.Stdout,
+	})
+	var typeErrs lang.TypeErrors
+	if errors.As(err, &typeErrs) {
+		reportTypes(os.Stdout, typeErrs)
+	}
+	return lang.ExitCode(err)
 }
 
-func interpret(node ast.Node, variablesTable *interfaces.VariablesTable) error {
-	println("INTEPRETED")
+// reportSyntax shows the errors of the parser and the lexer, and tells
+// whether there were any.
+func reportSyntax(w io.Writer, errs []error) bool {
+	for _, e := range errs {
+		if le, ok := e.(*lang.LexParseErr); ok && le.Lexer {
+			fmt.Fprintln(w, "Lexer Error", e)
+		} else {
+			fmt.Fprintln(w, "Parser Error", e)
+		}
+	}
+	return len(errs) > 0
+}
+
+func reportTypes(w io.Writer, errs []error) {
+	for _, e := range errs {
+		fmt.Fprintln(w, "Type Error", e)
+	}
+}
 
-	return nil
+// translate writes the program in another language to the output file, or
+// to stdout when it is empty.
+func translate(program ast.Node, lang, output string, overflow ast.Overflow) error {
+	if lang != "go" {
+		return fmt.Errorf("cannot emit %s, only go is supported", lang)
+	}
+	src, err := emit.Go(program, overflow)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(output, src, 0644)
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// result is what running a program shows.
+type result struct {
+	stdout, stderr string
+	status         int
+}
+
+// execute runs the command with the input and returns what it shows, failing
+// the test when it can't be started.
+func execute(t *testing.T, input []byte, dir, name string, args ...string) result {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return result{stdout: stdout.String(), stderr: stderr.String(), status: cmd.ProcessState.ExitCode()}
+}
+
+// TestEngines runs every sample of test/ with the tree interpreter, the
+// virtual machine and as emitted Go, which must all show the same. A sample
+// reads the .in file of the same name as its input, if there is one.
+func TestEngines(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds every sample with the Go toolchain")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the Go toolchain is not installed")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := filepath.Join(t.TempDir(), "aug")
+	if out, err := exec.Command(goTool, "build", "-o", compiler, ".").CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	samples, err := filepath.Glob(filepath.Join("test", "*.txt"))
+	if err != nil || len(samples) == 0 {
+		t.Fatalf("no samples: %v", err)
+	}
+	for _, sample := range samples {
+		sample := sample
+		t.Run(filepath.Base(sample), func(t *testing.T) {
+			t.Parallel()
+			input, err := os.ReadFile(strings.TrimSuffix(sample, ".txt") + ".in")
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				t.Fatal(err)
+			}
+
+			tree := execute(t, input, wd, compiler, "-engine", "tree", sample)
+			vm := execute(t, input, wd, compiler, "-engine", "vm", sample)
+			if vm != tree {
+				t.Errorf("vm shows %+v, tree shows %+v", vm, tree)
+			}
+
+			// The emitted program is built on its own, programs which don't
+			// check fail to translate with the errors the engines show.
+			dir := t.TempDir()
+			emitted := execute(t, nil, wd, compiler, "-emit=go", "-o", filepath.Join(dir, "main.go"), sample)
+			if emitted.status == 0 {
+				build := execute(t, nil, dir, goTool, "build", "-o", "program", "main.go")
+				if build.status != 0 {
+					t.Fatalf("go build of the emitted program: %s", build.stderr)
+				}
+				emitted = execute(t, input, wd, filepath.Join(dir, "program"))
+			}
+			if emitted != tree {
+				t.Errorf("emitted Go shows %+v, tree shows %+v", emitted, tree)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"aug/ast"
+	"aug/interfaces"
+	"aug/lang"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	prompt             = "aug> "
+	continuationPrompt = "...> "
+)
+
+const replHelp = `Type statements ending with ; to run them, or an expression to see its value.
+Statements spanning several lines are continued until they are complete, an
+empty line stops the continuation.
+
+  :vars         list the variables and their values
+  :reset        forget all variables and functions
+  :load <file>  run a program in this session
+  :ast <code>   show the syntax tree of an expression or statements
+  :help         show this help
+  :quit         leave the REPL, as does Ctrl-D
+`
+
+// repl is an interactive session. The statements typed into it run one at a
+// time, while the variables, functions and types they define are kept.
+type repl struct {
+	in       lineReader
+	stdin    io.Reader // what programs read, through the buffer the lines are read from
+	out      io.Writer
+	overflow ast.Overflow
+	strict   bool
+
+	variablesTable *interfaces.VariablesTable
+	interpreter    *ast.Interpreter
+	checker        *ast.Checker
+
+	exit *ast.ExitError // set once a statement exits
+}
+
+// runREPL reads and runs statements from in until it ends or a statement
+// exits, and returns the exit status. Terminals get a prompt and line
+// editing, anything else is read line by line.
+func runREPL(in *os.File, out io.Writer, overflow ast.Overflow, strict bool) int {
+	r := &repl{out: out, overflow: overflow, strict: strict}
+	if isTerminal(int(in.Fd())) {
+		editor := newLineEditor(in, out)
+		r.in, r.stdin = editor, editor.keys
+		fmt.Fprintln(out, "AUG REPL, type :help for the list of commands.")
+	} else {
+		plain := &plainReader{in: bufio.NewReader(in)}
+		r.in, r.stdin = plain, plain.in
+	}
+	r.reset()
+	r.loop()
+	if r.exit != nil {
+		return r.exit.Status
+	}
+	return 0
+}
+
+// reset starts over with an empty session.
+func (r *repl) reset() {
+	variablesTable := interfaces.MakeVariablesTable()
+	variablesTable.Strict = r.strict
+	r.variablesTable = &variablesTable
+	r.interpreter = &ast.Interpreter{VariablesTable: r.variablesTable, Stdin: r.stdin, Stdout: r.out, Overflow: r.overflow}
+	r.checker = ast.NewChecker()
+	r.checker.Strict = r.strict
+}
+
+func (r *repl) loop() {
+	var lines []string // the lines of an incomplete statement
+	for {
+		p := prompt
+		if len(lines) > 0 {
+			p = continuationPrompt
+		}
+		line, err := r.in.readLine(p)
+		if err == errInterrupted {
+			lines = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		if len(lines) == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			if strings.HasPrefix(trimmed, ":") {
+				r.in.addHistory(trimmed)
+				if !r.command(trimmed) || r.exit != nil {
+					return
+				}
+				continue
+			}
+		}
+
+		lines = append(lines, line)
+		// An empty line runs the statement even if it is incomplete, which
+		// shows the parser error instead of waiting for more.
+		if !r.eval(strings.Join(lines, "\n"), line == "") {
+			continue
+		}
+		r.in.addHistory(strings.Join(lines, " "))
+		lines = nil
+		if r.exit != nil {
+			return
+		}
+	}
+}
+
+// eval runs the source as an expression, whose value is shown, or else as
+// statements. It returns false when the statements are incomplete, unless
+// force is set.
+func (r *repl) eval(src string, force bool) bool {
+	if node, errs := lang.ParseExpr(strings.NewReader(src)); len(errs) == 0 {
+		r.run(node, true)
+		return true
+	}
+
+	node, errs := lang.Parse(strings.NewReader(src))
+	if lang.Incomplete(errs) && !force {
+		return false
+	}
+	if r.report(node, errs) {
+		r.run(node, false)
+	}
+	return true
+}
+
+// run checks and interprets the node within the session. The types it
+// defines are kept once it checks, even when it fails at runtime, as the
+// variables and functions it defined before the error are kept too.
+func (r *repl) run(node ast.Node, echo bool) {
+	checker := r.checker.Clone()
+	if errs := checker.CheckProgram(node); len(errs) > 0 {
+		reportTypes(r.out, errs)
+		return
+	}
+	r.checker = checker
+
+	result, err := node.Interpret(r.interpreter)
+	if errors.As(err, &r.exit) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(r.out, ast.Report(err))
+		return
+	}
+	if text, ok := formatResult(result); ok && echo {
+		fmt.Fprintln(r.out, text)
+	}
+}
+
+// report shows the errors of the lexer and the parser, and tells whether
+// there is an AST to run.
+func (r *repl) report(node ast.Node, errs []error) bool {
+	if reportSyntax(r.out, errs) {
+		return false
+	}
+	if node == nil {
+		fmt.Fprintln(r.out, "No AST was generated by the parser.")
+		return false
+	}
+	return true
+}
+
+// command runs a meta-command, it returns false when the REPL should stop.
+func (r *repl) command(line string) bool {
+	name, arg := line, ""
+	if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+		name, arg = line[:idx], strings.TrimSpace(line[idx+1:])
+	}
+
+	switch name {
+	case ":vars":
+		for _, name := range r.variablesTable.Names() {
+			value, _ := r.variablesTable.GetValue(name)
+			fmt.Fprintf(r.out, "%s = %s\n", name, formatValue(value))
+		}
+	case ":reset":
+		r.reset()
+		fmt.Fprintln(r.out, "All variables and functions were removed.")
+	case ":load":
+		if arg == "" {
+			fmt.Fprintln(r.out, "Usage: :load <file>")
+			break
+		}
+		file, err := os.Open(arg)
+		if err != nil {
+			fmt.Fprintf(r.out, "Error opening file: %s\n", err)
+			break
+		}
+		node, errs := lang.ParseFile(file, arg)
+		file.Close()
+		if r.report(node, errs) {
+			r.run(node, false)
+		}
+	case ":ast":
+		if arg == "" {
+			fmt.Fprintln(r.out, "Usage: :ast <code>")
+			break
+		}
+		node, errs := lang.ParseExpr(strings.NewReader(arg))
+		if len(errs) > 0 {
+			node, errs = lang.Parse(strings.NewReader(arg))
+		}
+		if r.report(node, errs) {
+			ast.Dump(r.out, node)
+		}
+	case ":help":
+		fmt.Fprint(r.out, replHelp)
+	case ":quit", ":q":
+		return false
+	default:
+		fmt.Fprintf(r.out, "Unknown command %s, type :help for the list of commands.\n", name)
+	}
+	return true
+}
+
+// formatResult shows the value an expression evaluated to.
+func formatResult(node ast.Node) (string, bool) {
+	switch v := node.(type) {
+	case *ast.NumLiteralNode:
+		return strconv.Itoa(v.Value), true
+	case *ast.StringLiteral:
+		return strconv.Quote(v.Value), true
+	case *ast.BoolLiteral:
+		return strconv.FormatBool(v.Value), true
+	case *ast.ArrayLiteral:
+		elems := make([]string, len(v.Elems))
+		for idx, elem := range v.Elems {
+			elems[idx], _ = formatResult(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]", true
+	}
+	return "", false
+}
+
+// formatValue shows the value of a variable.
+func formatValue(value interfaces.Value) string {
+	switch value.Type {
+	case interfaces.INTEGER_VALUE:
+		return strconv.Itoa(value.Int)
+	case interfaces.STRING_VALUE:
+		return strconv.Quote(value.Str)
+	case interfaces.BOOLEAN_VALUE:
+		return strconv.FormatBool(value.Bool)
+	case interfaces.ARRAY_VALUE:
+		elems := make([]string, len(value.Elems))
+		for idx, elem := range value.Elems {
+			elems[idx] = formatValue(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// session runs the lines in a new REPL session and returns what it shows.
+func session(t *testing.T, lines ...string) string {
+	t.Helper()
+	out := &bytes.Buffer{}
+	plain := &plainReader{in: bufio.NewReader(strings.NewReader(strings.Join(lines, "\n") + "\n"))}
+	r := &repl{in: plain, stdin: plain.in, out: out}
+	r.reset()
+	r.loop()
+	return out.String()
+}
+
+func TestREPLRuntimeError(t *testing.T) {
+	program := filepath.Join(t.TempDir(), "program.aug")
+	src := "function twice(n) begin return 2 * n; end;\nz := 1 / 0;\n"
+	if err := os.WriteFile(program, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name:  "variable assigned before the error",
+			lines: []string{`x := 1; y := 1 / 0;`, `print(x);`, `x := "s";`},
+			want: "division by zero: 1 / 0 @1:14\n" +
+				"1\n" +
+				"Type Error cannot change the type of variable x from int to string @1:1\n",
+		},
+		{
+			name:  "function of a loaded file",
+			lines: []string{":load " + program, `print(twice(21));`},
+			want:  "42\n",
+		},
+		{
+			name:  "input which doesn't check",
+			lines: []string{`x := 1; print(concatenate(x, "a"));`, `x := "s";`, `print(x);`},
+			want: "Type Error concatenate expected string, got int @1:27\n" +
+				"s\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := session(t, test.lines...)
+			if !strings.HasSuffix(got, test.want) {
+				t.Fatalf("got %q, want it to end with %q", got, test.want)
+			}
+		})
+	}
+}
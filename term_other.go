@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import "errors"
+
+// isTerminal tells whether the file descriptor is an interactive terminal.
+// Terminals are only detected on Linux, elsewhere the REPL has to be started
+// with -i and reads plain lines.
+func isTerminal(fd int) bool {
+	return false
+}
+
+// enableRawMode is not supported on this platform.
+func enableRawMode(fd int) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported")
+}
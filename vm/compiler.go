@@ -0,0 +1,713 @@
+package vm
+
+import (
+	"aug/ast"
+	"fmt"
+)
+
+// scope maps the variables of a block to the slots of the frame. It mirrors
+// the interfaces.VariablesTable scopes of the tree-walking interpreter.
+type scope struct {
+	parent *scope
+	vars   map[string]int
+	block  bool
+}
+
+func (s *scope) lookup(name string) (int, bool) {
+	for ; s != nil; s = s.parent {
+		if slot, ok := s.vars[name]; ok {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+// loop collects the jumps of break and continue statements, which are
+// patched once the loop is compiled.
+type loop struct {
+	breaks    []int
+	continues []int
+}
+
+// pendingFunction is a function whose body is compiled after the top-level
+// code, once every variable of the scope it was declared in is known.
+type pendingFunction struct {
+	decl  *ast.FunctionDeclNode
+	fn    *Function
+	scope *scope
+}
+
+type compiler struct {
+	program   *Program
+	functions map[string]int
+	decls     map[string]*ast.FunctionDeclNode
+	constants map[string]int
+	pending   []pendingFunction
+
+	// The frame being compiled. Variables which are not found in scope are
+	// looked up in outer, the top-level scope a function was declared in.
+	scope    *scope
+	outer    *scope
+	function *Function
+	slots    int
+	loops    []*loop
+}
+
+// Compile translates a type checked AST into bytecode.
+func Compile(program ast.Node) (*Program, error) {
+	c := &compiler{
+		program:   &Program{},
+		functions: make(map[string]int),
+		decls:     make(map[string]*ast.FunctionDeclNode),
+		constants: make(map[string]int),
+		scope:     &scope{vars: make(map[string]int)},
+	}
+
+	if err := c.statement(program); err != nil {
+		return nil, err
+	}
+	c.emit(HALT, 0, 0, program.Position())
+	c.program.Globals = c.slots
+
+	for idx := 0; idx < len(c.pending); idx++ {
+		if err := c.functionBody(c.pending[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return c.program, nil
+}
+
+func (c *compiler) emit(op Opcode, a, b int, pos ast.Pos) int {
+	c.program.Code = append(c.program.Code, Instruction{Op: op, A: a, B: b})
+	c.program.Positions = append(c.program.Positions, pos)
+	return len(c.program.Code) - 1
+}
+
+// patch makes the jump at index continue at the next emitted instruction.
+func (c *compiler) patch(jumps ...int) {
+	for _, jump := range jumps {
+		c.program.Code[jump].A = len(c.program.Code)
+	}
+}
+
+func (c *compiler) constant(value string) int {
+	if idx, ok := c.constants[value]; ok {
+		return idx
+	}
+	c.program.Constants = append(c.program.Constants, value)
+	c.constants[value] = len(c.program.Constants) - 1
+	return len(c.program.Constants) - 1
+}
+
+// typed fails unless the Checker inferred the type of the node, the
+// instructions take every value to be of the type it was checked to be.
+func typed(t ast.Type, what string, pos ast.Pos) error {
+	if t == ast.INVALID_TYPE {
+		return fmt.Errorf("vm: cannot compile %s of unknown type @%s", what, pos.Location())
+	}
+	return nil
+}
+
+// called tells whether the Checker inferred the parameters of the function
+// from a call. The body of a function which is never called is left out, its
+// parameters have no type.
+func called(decl *ast.FunctionDeclNode) bool {
+	for _, t := range decl.ParamTypes {
+		if t == ast.INVALID_TYPE {
+			return false
+		}
+	}
+	return true
+}
+
+// fail compiles an instruction which stops the program with an error.
+func (c *compiler) fail(pos ast.Pos, format string, args ...interface{}) {
+	c.emit(FAIL, c.constant(fmt.Sprintf(format, args...)), 0, pos)
+}
+
+// construct traces the errors of the instructions from start up to the next
+// one to the frame. The jump back of a loop is left out of it, as the
+// interpreter checks whether the program is interrupted outside of the loop
+// body. Counter is the slot of the counter of a for loop, or -1.
+func (c *compiler) construct(frame ast.Frame, start, counter int) {
+	c.program.Constructs = append(c.program.Constructs, Construct{
+		Frame:   frame,
+		Start:   start,
+		End:     len(c.program.Code),
+		Counter: counter,
+	})
+}
+
+func (c *compiler) newSlot() int {
+	c.slots++
+	return c.slots - 1
+}
+
+// assign returns the slot an assignment writes to. Like
+// interfaces.VariablesTable.SetValue, assignments write to the variable of
+// the blocks they are in, or else create it in the innermost scope.
+func (c *compiler) assign(name string) int {
+	for s := c.scope; s != nil; s = s.parent {
+		if slot, ok := s.vars[name]; ok {
+			return slot
+		}
+		if !s.block {
+			break
+		}
+	}
+	return c.declare(name)
+}
+
+// declare returns a new slot for the variable in the innermost scope.
+func (c *compiler) declare(name string) int {
+	slot := c.newSlot()
+	c.scope.vars[name] = slot
+	return slot
+}
+
+func (c *compiler) functionBody(p pendingFunction) error {
+	c.scope = &scope{vars: make(map[string]int)}
+	c.outer = p.scope
+	c.function = p.fn
+	c.slots = 0
+	c.loops = nil
+
+	for _, param := range p.decl.Params {
+		c.assign(param)
+	}
+	p.fn.Entry = len(c.program.Code)
+	if !called(p.decl) {
+		c.fail(p.decl.Pos, "function %s is never called", p.decl.Name)
+		p.fn.Slots = c.slots
+		return nil
+	}
+	if err := c.statement(p.decl.Body); err != nil {
+		return err
+	}
+	if p.decl.Procedure {
+		c.emit(RETURN_VOID, 0, 0, p.decl.Pos)
+	} else {
+		c.emit(NO_RETURN, c.functions[p.decl.Name], 0, p.decl.Pos)
+	}
+	p.fn.Slots = c.slots
+	return nil
+}
+
+func (c *compiler) statement(node ast.Node) error {
+	switch n := node.(type) {
+	case *ast.NodeSequence:
+		return c.statements(n.Nodes)
+
+	case *ast.BlockNode:
+		start := len(c.program.Code)
+		c.scope = &scope{parent: c.scope, vars: make(map[string]int), block: true}
+		// Variables assigned in the block are new every time it runs.
+		clear := -1
+		for _, statement := range n.Statements {
+			if assignsVariables(statement) {
+				clear = c.emit(CLEAR, c.slots, 0, n.Pos)
+				break
+			}
+		}
+		if err := c.statements(n.Statements); err != nil {
+			return err
+		}
+		if clear >= 0 {
+			c.program.Code[clear].B = c.slots - c.program.Code[clear].A
+		}
+		c.scope = c.scope.parent
+		c.construct(ast.Frame{Construct: "block", Pos: n.Pos}, start, -1)
+
+	case *ast.AssignStatNode:
+		if err := c.expression(n.Value); err != nil {
+			return err
+		}
+		c.emit(STORE, c.assign(n.Identifier), c.constant(n.Identifier), n.Pos)
+
+	case *ast.VarDeclNode:
+		if err := c.expression(n.Value); err != nil {
+			return err
+		}
+		c.emit(STORE, c.declare(n.Identifier), c.constant(n.Identifier), n.Pos)
+
+	case *ast.IndexAssignStatNode:
+		c.load(n.Identifier, n.Pos)
+		for _, arg := range []ast.Node{n.Index, n.Value} {
+			if err := c.expression(arg); err != nil {
+				return err
+			}
+		}
+		c.emit(SET_INDEX, 0, 0, n.Index.Position())
+
+	case *ast.PrintStatNode:
+		for _, value := range n.Values {
+			if err := c.expression(value); err != nil {
+				return err
+			}
+		}
+		newline := 0
+		if n.Newline {
+			newline = 1
+		}
+		c.emit(PRINT, len(n.Values), newline, n.Pos)
+
+	case *ast.PrintfNode:
+		for _, arg := range n.Args {
+			if err := c.expression(arg); err != nil {
+				return err
+			}
+		}
+		c.emit(PRINTF, c.constant(n.Format), len(n.Args), n.Pos)
+
+	case *ast.IfStatNode:
+		if err := c.expression(n.Condition); err != nil {
+			return err
+		}
+		skipThen := c.emit(JUMP_IF_FALSE, 0, 0, n.Pos)
+		if err := c.statement(n.ThenBranch); err != nil {
+			return err
+		}
+		if n.ElseBranch == nil {
+			c.patch(skipThen)
+			break
+		}
+		skipElse := c.emit(JUMP, 0, 0, n.Pos)
+		c.patch(skipThen)
+		if err := c.statement(n.ElseBranch); err != nil {
+			return err
+		}
+		c.patch(skipElse)
+
+	case *ast.ForStatNode:
+		// The counter and the final value live in hidden slots, so the body
+		// cannot change the number of iterations, like in the interpreter.
+		counter, final := c.newSlot(), c.newSlot()
+		if err := c.expression(n.Initial); err != nil {
+			return err
+		}
+		c.emit(STORE, counter, -1, n.Pos)
+		if err := c.expression(n.Final); err != nil {
+			return err
+		}
+		c.emit(STORE, final, -1, n.Pos)
+
+		c.emit(LOAD, counter, -1, n.Pos)
+		c.emit(LOAD, final, -1, n.Pos)
+		c.emit(LE, 0, 0, n.Pos)
+		empty := c.emit(JUMP_IF_FALSE, 0, 0, n.Pos)
+		start := c.emit(LOAD, counter, -1, n.Pos)
+		c.emit(STORE, c.assign(n.Identifier), c.constant(n.Identifier), n.Pos)
+
+		l, err := c.loopBody(n.Body)
+		if err != nil {
+			return err
+		}
+		// The counter is only incremented while it is below the final value,
+		// which may be the largest integer.
+		c.patch(l.continues...)
+		c.emit(LOAD, counter, -1, n.Pos)
+		c.emit(LOAD, final, -1, n.Pos)
+		c.emit(LT, 0, 0, n.Pos)
+		exit := c.emit(JUMP_IF_FALSE, 0, 0, n.Pos)
+		c.emit(LOAD, counter, -1, n.Pos)
+		c.emit(PUSH_INT, 1, 0, n.Pos)
+		c.emit(ADD, 0, 0, n.Pos)
+		c.emit(STORE, counter, -1, n.Pos)
+		c.construct(ast.Frame{Construct: "for", Name: n.Identifier, Pos: n.Pos}, start, counter)
+		c.emit(JUMP, start, 0, n.Pos)
+		c.patch(empty, exit)
+		c.patch(l.breaks...)
+
+	case *ast.WhileStatNode:
+		start := len(c.program.Code)
+		if err := c.expression(n.Condition); err != nil {
+			return err
+		}
+		exit := c.emit(JUMP_IF_FALSE, 0, 0, n.Pos)
+		l, err := c.loopBody(n.Body)
+		if err != nil {
+			return err
+		}
+		c.patch(l.continues...)
+		c.construct(ast.Frame{Construct: "while", Pos: n.Pos}, start, -1)
+		c.emit(JUMP, start, 0, n.Pos)
+		c.patch(exit)
+		c.patch(l.breaks...)
+
+	case *ast.RepeatStatNode:
+		start := len(c.program.Code)
+		l, err := c.loopBody(n.Body)
+		if err != nil {
+			return err
+		}
+		c.patch(l.continues...)
+		if err := c.expression(n.Condition); err != nil {
+			return err
+		}
+		c.construct(ast.Frame{Construct: "repeat", Pos: n.Pos}, start, -1)
+		c.emit(JUMP_IF_FALSE, start, 0, n.Pos)
+		c.patch(l.breaks...)
+
+	case *ast.BreakNode:
+		if len(c.loops) == 0 {
+			c.fail(n.Pos, "break outside of a loop")
+			break
+		}
+		l := c.loops[len(c.loops)-1]
+		l.breaks = append(l.breaks, c.emit(JUMP, 0, 0, n.Pos))
+
+	case *ast.ContinueNode:
+		if len(c.loops) == 0 {
+			c.fail(n.Pos, "continue outside of a loop")
+			break
+		}
+		l := c.loops[len(c.loops)-1]
+		l.continues = append(l.continues, c.emit(JUMP, 0, 0, n.Pos))
+
+	case *ast.ExitNode:
+		if n.Status == nil {
+			c.emit(PUSH_INT, 0, 0, n.Pos)
+		} else if err := c.expression(n.Status); err != nil {
+			return err
+		}
+		c.emit(EXIT, 0, 0, n.Pos)
+
+	case *ast.PragmaNode:
+		// the Checker already enforced it
+
+	case *ast.FunctionDeclNode:
+		if _, found := c.functions[n.Name]; found {
+			c.fail(n.Pos, "function %s is already declared", n.Name)
+			break
+		}
+		// every parameter is a slot of its own
+		if param, found := n.RepeatedParam(); found {
+			return fmt.Errorf("vm: parameter %s of %s is declared twice @%s", param, n.Name, n.Pos.Location())
+		}
+		fn := &Function{Name: n.Name, Params: len(n.Params), Procedure: n.Procedure}
+		c.program.Functions = append(c.program.Functions, fn)
+		c.functions[n.Name] = len(c.program.Functions) - 1
+		c.decls[n.Name] = n
+		c.pending = append(c.pending, pendingFunction{decl: n, fn: fn, scope: c.scope})
+		c.emit(DECLARE, c.functions[n.Name], 0, n.Pos)
+
+	case *ast.CallNode:
+		fn, err := c.call(n)
+		if err != nil {
+			return err
+		}
+		if fn != nil && !fn.Procedure {
+			c.emit(POP, 0, 0, n.Pos)
+		}
+	case *ast.BuiltinNode:
+		if err := c.builtin(n); err != nil {
+			return err
+		}
+		c.emit(POP, 0, 0, n.Pos)
+
+	case *ast.ReturnNode:
+		switch {
+		case c.function == nil:
+			c.fail(n.Pos, "return outside of a function")
+		case n.Value == nil:
+			c.emit(RETURN_VOID, 0, 0, n.Pos)
+		default:
+			if err := c.expression(n.Value); err != nil {
+				return err
+			}
+			c.emit(RETURN, 0, 0, n.Pos)
+		}
+
+	default:
+		return fmt.Errorf("vm: unsupported statement %T", node)
+	}
+	return nil
+}
+
+func (c *compiler) statements(nodes []ast.Node) error {
+	for _, node := range nodes {
+		if err := c.statement(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loopBody compiles the body of a loop and returns its break and continue
+// jumps to be patched by the caller.
+func (c *compiler) loopBody(body ast.Node) (*loop, error) {
+	l := &loop{}
+	c.loops = append(c.loops, l)
+	err := c.statement(body)
+	c.loops = c.loops[:len(c.loops)-1]
+	return l, err
+}
+
+// assignsVariables reports whether the statement may create a variable in
+// the scope it runs in.
+func assignsVariables(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.AssignStatNode, *ast.VarDeclNode, *ast.ForStatNode:
+		return true
+	case *ast.NodeSequence:
+		for _, statement := range n.Nodes {
+			if assignsVariables(statement) {
+				return true
+			}
+		}
+	case *ast.IfStatNode:
+		return assignsVariables(n.ThenBranch) || (n.ElseBranch != nil && assignsVariables(n.ElseBranch))
+	case *ast.WhileStatNode:
+		return assignsVariables(n.Body)
+	case *ast.RepeatStatNode:
+		return assignsVariables(n.Body)
+	}
+	return false
+}
+
+// call compiles the arguments and the call of a user-defined function. The
+// returned function is nil when it is not declared.
+func (c *compiler) call(n *ast.CallNode) (*Function, error) {
+	for _, arg := range n.Args {
+		if err := c.expression(arg); err != nil {
+			return nil, err
+		}
+	}
+	idx, ok := c.functions[n.Name]
+	if !ok {
+		c.fail(n.Pos, "undefined function: %s", n.Name)
+		return nil, nil
+	}
+	fn := c.program.Functions[idx]
+	if len(n.Args) != fn.Params {
+		c.fail(n.Pos, "%s expects %d arguments, got %d", n.Name, fn.Params, len(n.Args))
+		return nil, nil
+	}
+	c.emit(CALL, idx, 0, n.Pos)
+	return fn, nil
+}
+
+// builtin compiles a call of a library function.
+func (c *compiler) builtin(n *ast.BuiltinNode) error {
+	for _, arg := range n.Args {
+		if err := c.expression(arg); err != nil {
+			return err
+		}
+	}
+	b, ok := ast.Builtins[n.Name]
+	if !ok {
+		c.fail(n.Pos, "undefined function: %s", n.Name)
+		return nil
+	}
+	if err := b.CheckCount(n.Name, len(n.Args)); err != nil {
+		c.fail(n.Pos, "%s", err)
+		return nil
+	}
+	c.emit(BUILTIN, c.constant(n.Name), len(n.Args), n.Pos)
+	return nil
+}
+
+var comparisons = map[string]Opcode{
+	"=":  EQ,
+	"<>": NE,
+	"<":  LT,
+	"<=": LE,
+	">":  GT,
+	">=": GE,
+	"==": STR_EQ,
+	"!=": STR_NE,
+}
+
+var arithmetic = map[string]Opcode{
+	"+": ADD,
+	"-": SUB,
+	"*": MUL,
+	"/": DIV,
+	"%": MOD,
+}
+
+func (c *compiler) binary(op Opcode, left, right ast.Node, pos ast.Pos) error {
+	if err := c.expression(left); err != nil {
+		return err
+	}
+	if err := c.expression(right); err != nil {
+		return err
+	}
+	c.emit(op, 0, 0, pos)
+	return nil
+}
+
+// radix compiles a conversion whose radix is optional, it is 10 when the
+// program doesn't give one.
+func (c *compiler) radix(op Opcode, value, radix ast.Node, pos ast.Pos) error {
+	if radix == nil {
+		radix = &ast.NumLiteralNode{Pos: pos, Value: 10}
+	}
+	return c.binary(op, value, radix, pos)
+}
+
+func (c *compiler) expression(node ast.Node) error {
+	switch n := node.(type) {
+	case *ast.NumLiteralNode:
+		c.emit(PUSH_INT, n.Value, 0, n.Pos)
+
+	case *ast.StringLiteral:
+		c.emit(PUSH_STR, c.constant(n.Value), 0, n.Pos)
+
+	case *ast.BoolLiteral:
+		value := 0
+		if n.Value {
+			value = 1
+		}
+		c.emit(PUSH_BOOL, value, 0, n.Pos)
+
+	case *ast.VariableReferenceNode:
+		if err := typed(n.Type, "variable "+n.Name, n.Pos); err != nil {
+			return err
+		}
+		c.load(n.Name, n.Pos)
+
+	case *ast.ArrayLiteral:
+		if err := typed(n.Type, "array", n.Pos); err != nil {
+			return err
+		}
+		for _, elem := range n.Elems {
+			if err := c.expression(elem); err != nil {
+				return err
+			}
+		}
+		elem := INT
+		if n.Type == ast.STRING_ARRAY_TYPE {
+			elem = STRING
+		}
+		c.emit(MAKE_ARRAY, len(n.Elems), int(elem), n.Pos)
+	case *ast.IndexNode:
+		if err := typed(n.Type, "array element", n.Pos); err != nil {
+			return err
+		}
+		// bounds errors are reported at the index
+		return c.binary(INDEX, n.Array, n.Index, n.Index.Position())
+
+	case *ast.NumExprNode:
+		op, ok := arithmetic[n.Op]
+		if !ok {
+			return fmt.Errorf("vm: integer operation not supported: %s", n.Op)
+		}
+		return c.binary(op, n.Left, n.Right, n.Pos)
+
+	case *ast.UnaryOpNode:
+		if err := c.expression(n.Operand); err != nil {
+			return err
+		}
+		if n.Op == "!" {
+			c.emit(NOT, 0, 0, n.Pos)
+		} else {
+			c.emit(NEG, 0, 0, n.Pos)
+		}
+
+	case *ast.BoolExprNode:
+		return c.comparison(n.Op, n.Left, n.Right, n.Pos)
+	case *ast.NumComparisonExprNode:
+		return c.comparison(n.Op, n.Left, n.Right, n.Pos)
+	case *ast.StrComparisonExprNode:
+		return c.comparison(n.Op, n.Left, n.Right, n.Pos)
+
+	case *ast.LogicalExprNode:
+		if n.Op == "xor" {
+			return c.binary(XOR, n.Left, n.Right, n.Pos)
+		}
+		// Short-circuit: the right operand only runs when the left one
+		// doesn't decide the result already.
+		if err := c.expression(n.Left); err != nil {
+			return err
+		}
+		jump, decided := JUMP_IF_FALSE, 0
+		if n.Op == "or" {
+			jump, decided = JUMP_IF_TRUE, 1
+		}
+		short := c.emit(jump, 0, 0, n.Pos)
+		if err := c.expression(n.Right); err != nil {
+			return err
+		}
+		done := c.emit(JUMP, 0, 0, n.Pos)
+		c.patch(short)
+		c.emit(PUSH_BOOL, decided, 0, n.Pos)
+		c.patch(done)
+
+	case *ast.ReadIntNode:
+		c.emit(READ_INT, 0, 0, n.Pos)
+	case *ast.ReadStr:
+		c.emit(READ_STR, 0, 0, n.Pos)
+	case *ast.ReadLn:
+		c.emit(READ_LN, 0, 0, n.Pos)
+	case *ast.EOFNode:
+		c.emit(AT_EOF, 0, 0, n.Pos)
+
+	case *ast.LengthNode:
+		if err := c.expression(n.Str); err != nil {
+			return err
+		}
+		c.emit(LENGTH, 0, 0, n.Pos)
+	case *ast.PositionNode:
+		return c.binary(POSITION, n.Str, n.Substr, n.Pos)
+	case *ast.Concatenate:
+		return c.binary(CONCAT, n.Left, n.Right, n.Pos)
+	case *ast.Substring:
+		for _, arg := range []ast.Node{n.Str, n.Start, n.Length} {
+			if err := c.expression(arg); err != nil {
+				return err
+			}
+		}
+		c.emit(SUBSTRING, 0, 0, n.Pos)
+	case *ast.StrNode:
+		return c.radix(FORMAT_INT, n.Value, n.Radix, n.Pos)
+	case *ast.IntNode:
+		return c.radix(PARSE_INT, n.Str, n.Radix, n.Pos)
+	case *ast.IsIntNode:
+		return c.radix(IS_INT, n.Str, n.Radix, n.Pos)
+	case *ast.ChrNode:
+		if err := c.expression(n.Code); err != nil {
+			return err
+		}
+		c.emit(CHR, 0, 0, n.Pos)
+	case *ast.OrdNode:
+		if err := c.expression(n.Str); err != nil {
+			return err
+		}
+		c.emit(ORD, 0, 0, n.Pos)
+
+	case *ast.CallNode:
+		if decl, ok := c.decls[n.Name]; ok && !decl.Procedure {
+			if err := typed(decl.Result, "result of "+n.Name, n.Pos); err != nil {
+				return err
+			}
+		}
+		_, err := c.call(n)
+		return err
+	case *ast.BuiltinNode:
+		return c.builtin(n)
+
+	default:
+		return fmt.Errorf("vm: unsupported expression %T", node)
+	}
+	return nil
+}
+
+// load compiles reading a variable.
+func (c *compiler) load(name string, pos ast.Pos) {
+	if slot, ok := c.scope.lookup(name); ok {
+		c.emit(LOAD, slot, c.constant(name), pos)
+	} else if slot, ok := c.outer.lookup(name); ok && c.function != nil {
+		c.emit(LOAD_GLOBAL, slot, c.constant(name), pos)
+	} else {
+		c.fail(pos, "undefined variable: %s", name)
+	}
+}
+
+func (c *compiler) comparison(op string, left, right ast.Node, pos ast.Pos) error {
+	opcode, ok := comparisons[op]
+	if !ok {
+		return fmt.Errorf("vm: comparison operation not supported: %s", op)
+	}
+	return c.binary(opcode, left, right, pos)
+}
@@ -0,0 +1,180 @@
+package vm
+
+import (
+	"aug/ast"
+	"fmt"
+)
+
+// Opcode is a single operation of the virtual machine. Operands are popped
+// from and results pushed to the operand stack, A and B of the Instruction
+// hold the immediate arguments.
+type Opcode byte
+
+const (
+	PUSH_INT  Opcode = iota // push the integer A
+	PUSH_STR                // push the string constant A
+	PUSH_BOOL               // push true when A is 1, false when it is 0
+
+	LOAD         // push local slot A, B is the constant holding its name
+	STORE        // pop into local slot A, B is the constant holding its name
+	LOAD_GLOBAL  // push global slot A, B is the constant holding its name
+	STORE_GLOBAL // pop into global slot A, B is the constant holding its name
+	CLEAR        // unset B local slots starting at A
+
+	ADD
+	SUB
+	MUL
+	DIV
+	MOD
+	NEG
+
+	EQ
+	NE
+	LT
+	LE
+	GT
+	GE
+	STR_EQ
+	STR_NE
+	NOT
+	XOR
+
+	JUMP          // continue at A
+	JUMP_IF_FALSE // pop, continue at A when it is false
+	JUMP_IF_TRUE  // pop, continue at A when it is true
+
+	PRINT  // pop A values and print them separated by spaces, and a line break when B is 1
+	PRINTF // pop B values and print them in the format of the constant A
+	READ_INT
+	READ_STR
+	READ_LN
+	AT_EOF // push whether the whole input was read
+	LENGTH
+	POSITION
+	CONCAT
+	SUBSTRING
+	FORMAT_INT // pop the radix and the integer, push its digits
+	PARSE_INT  // pop the radix and the string, push the integer written in it
+	IS_INT     // pop the radix and the string, push whether PARSE_INT reads it
+	CHR
+	ORD
+	BUILTIN // pop B arguments and push the result of the library function named by constant A
+
+	MAKE_ARRAY // pop A elements and push the array of them, with elements of kind B
+	INDEX      // pop the index and the array, push the element
+	SET_INDEX  // pop the element, the index and the array, and replace it
+
+	DECLARE     // make function A callable
+	CALL        // call function A with its arguments on the stack
+	RETURN      // return the value on top of the stack
+	RETURN_VOID // return from a procedure
+	NO_RETURN   // return from function A without a value, which fails at the call
+	POP         // discard the top of the stack
+
+	HALT // stop the program
+	EXIT // pop the exit status and stop the program with it
+	FAIL // stop the program with the error message in constant A
+)
+
+var opcodeNames = [...]string{
+	PUSH_INT:      "PUSH_INT",
+	PUSH_STR:      "PUSH_STR",
+	PUSH_BOOL:     "PUSH_BOOL",
+	LOAD:          "LOAD",
+	STORE:         "STORE",
+	LOAD_GLOBAL:   "LOAD_GLOBAL",
+	STORE_GLOBAL:  "STORE_GLOBAL",
+	CLEAR:         "CLEAR",
+	ADD:           "ADD",
+	SUB:           "SUB",
+	MUL:           "MUL",
+	DIV:           "DIV",
+	MOD:           "MOD",
+	NEG:           "NEG",
+	EQ:            "EQ",
+	NE:            "NE",
+	LT:            "LT",
+	LE:            "LE",
+	GT:            "GT",
+	GE:            "GE",
+	STR_EQ:        "STR_EQ",
+	STR_NE:        "STR_NE",
+	NOT:           "NOT",
+	XOR:           "XOR",
+	JUMP:          "JUMP",
+	JUMP_IF_FALSE: "JUMP_IF_FALSE",
+	JUMP_IF_TRUE:  "JUMP_IF_TRUE",
+	PRINT:         "PRINT",
+	PRINTF:        "PRINTF",
+	READ_INT:      "READ_INT",
+	READ_STR:      "READ_STR",
+	READ_LN:       "READ_LN",
+	AT_EOF:        "AT_EOF",
+	LENGTH:        "LENGTH",
+	POSITION:      "POSITION",
+	CONCAT:        "CONCAT",
+	SUBSTRING:     "SUBSTRING",
+	FORMAT_INT:    "FORMAT_INT",
+	PARSE_INT:     "PARSE_INT",
+	IS_INT:        "IS_INT",
+	CHR:           "CHR",
+	ORD:           "ORD",
+	BUILTIN:       "BUILTIN",
+	MAKE_ARRAY:    "MAKE_ARRAY",
+	INDEX:         "INDEX",
+	SET_INDEX:     "SET_INDEX",
+	DECLARE:       "DECLARE",
+	CALL:          "CALL",
+	RETURN:        "RETURN",
+	RETURN_VOID:   "RETURN_VOID",
+	NO_RETURN:     "NO_RETURN",
+	POP:           "POP",
+	HALT:          "HALT",
+	EXIT:          "EXIT",
+	FAIL:          "FAIL",
+}
+
+func (op Opcode) String() string {
+	if int(op) < len(opcodeNames) && opcodeNames[op] != "" {
+		return opcodeNames[op]
+	}
+	return fmt.Sprintf("Opcode(%d)", op)
+}
+
+type Instruction struct {
+	Op Opcode
+	A  int
+	B  int
+}
+
+// Function is a compiled user-defined function. Its parameters occupy the
+// first local slots of the frame.
+type Function struct {
+	Name      string
+	Entry     int // index of the first instruction
+	Params    int
+	Slots     int // number of local slots, including the parameters
+	Procedure bool
+}
+
+// Construct is a loop or a block of the source. The runtime errors of its
+// instructions, from Start up to End, are traced to Frame.
+type Construct struct {
+	Frame   ast.Frame
+	Start   int
+	End     int
+	Counter int // the slot of the counter of a for loop
+}
+
+// Program is the bytecode of a whole AUG program. The top-level code starts
+// at the first instruction and runs in a frame of Globals slots.
+type Program struct {
+	Code      []Instruction
+	Positions []ast.Pos // source position of every instruction
+	Constants []string
+	Functions []*Function
+	Globals   int
+	// Constructs are in the order they end in, so the inner ones come
+	// before the constructs they are part of.
+	Constructs []Construct
+}
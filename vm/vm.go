@@ -0,0 +1,526 @@
+package vm
+
+import (
+	"aug/ast"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxCallDepth matches the recursion limit of the tree-walking interpreter.
+const maxCallDepth = 10000
+
+// Kind is the runtime type of a Value.
+type Kind byte
+
+const (
+	UNSET Kind = iota // a slot which was never assigned
+	INT
+	STRING
+	BOOL
+	ARRAY
+)
+
+// Value is a single value on the operand stack or in a slot. Booleans are
+// stored in Int as 0 or 1, arrays in Elems, which are all of the kind Elem.
+type Value struct {
+	Kind  Kind
+	Int   int
+	Str   string
+	Elem  Kind
+	Elems []Value
+}
+
+// copied returns the value with its own elements, so that changing them
+// doesn't change the array it was copied from. Every slot owns the
+// elements of its array.
+func (v Value) copied() Value {
+	if v.Kind == ARRAY {
+		v.Elems = append([]Value(nil), v.Elems...)
+	}
+	return v
+}
+
+// arithmeticOps are the operators of the arithmetic instructions.
+var arithmeticOps = [...]string{ADD: "+", SUB: "-", MUL: "*", DIV: "/", MOD: "%"}
+
+type frame struct {
+	ret  int // instruction to continue at in the caller
+	base int // first local slot of the caller
+}
+
+type machine struct {
+	program  *Program
+	stack    []Value
+	locals   []Value
+	frames   []frame
+	declared []bool
+	in       *ast.Input
+	out      io.Writer
+	ctx      context.Context
+	overflow ast.Overflow
+}
+
+// Options are the streams of a running program, which must both be set, and
+// what its arithmetic does with results beyond 32 bits.
+type Options struct {
+	Stdin    io.Reader
+	Stdout   io.Writer
+	Overflow ast.Overflow
+}
+
+// Run executes the program until it ends, exits, fails or ctx is done.
+func Run(ctx context.Context, program *Program, opts Options) error {
+	m := &machine{
+		program:  program,
+		locals:   make([]Value, program.Globals),
+		declared: make([]bool, len(program.Functions)),
+		in:       ast.NewInput(opts.Stdin),
+		out:      opts.Stdout,
+		ctx:      ctx,
+		overflow: opts.Overflow,
+	}
+	return m.run()
+}
+
+// interrupted returns the error of the context once it is done. Only jumps
+// back and calls check it, as every loop and recursion goes through them.
+func (m *machine) interrupted(ip int) error {
+	select {
+	case <-m.ctx.Done():
+		return m.errorAt(ip, "%w", m.ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// errorAt builds a runtime error at the position of the instruction, with
+// the trace of the constructs and calls it happened in.
+func (m *machine) errorAt(ip int, format string, args ...interface{}) error {
+	return m.traced(ip, m.program.Positions[ip].Errorf(format, args...))
+}
+
+// kindErrorAt is errorAt for an error of the given kind.
+func (m *machine) kindErrorAt(ip int, kind ast.ErrorKind, format string, args ...interface{}) error {
+	return m.traced(ip, m.program.Positions[ip].KindErrorf(kind, format, args...))
+}
+
+func (m *machine) traced(ip int, err error) error {
+	re := err.(*ast.RuntimeError)
+	base := 0
+	if len(m.frames) > 0 {
+		fn := m.program.Functions[m.program.Code[m.frames[len(m.frames)-1].ret].A]
+		base = len(m.locals) - fn.Slots
+	}
+	for depth := len(m.frames); ; depth-- {
+		for _, construct := range m.program.Constructs {
+			if ip < construct.Start || ip >= construct.End {
+				continue
+			}
+			frame := construct.Frame
+			if frame.Construct == "for" {
+				frame.Counter = m.locals[base+construct.Counter].Int
+			}
+			re.Trace = append(re.Trace, frame)
+		}
+		if depth == 0 {
+			return re
+		}
+		caller := m.frames[depth-1]
+		re.Trace = append(re.Trace, ast.Frame{
+			Construct: "call",
+			Name:      m.program.Functions[m.program.Code[caller.ret].A].Name,
+			Pos:       m.program.Positions[caller.ret],
+		})
+		ip, base = caller.ret, caller.base
+	}
+}
+
+func (m *machine) run() error {
+	code := m.program.Code
+	stack := m.stack
+	base := 0
+
+	for ip := 0; ; ip++ {
+		in := code[ip]
+		switch in.Op {
+		case PUSH_INT:
+			stack = append(stack, Value{Kind: INT, Int: in.A})
+		case PUSH_STR:
+			stack = append(stack, Value{Kind: STRING, Str: m.program.Constants[in.A]})
+		case PUSH_BOOL:
+			stack = append(stack, Value{Kind: BOOL, Int: in.A})
+
+		case LOAD, LOAD_GLOBAL:
+			slot := in.A
+			if in.Op == LOAD {
+				slot += base
+			}
+			value := m.locals[slot]
+			if value.Kind == UNSET {
+				return m.kindErrorAt(ip, ast.UndefinedVariable, "undefined variable: %s", m.program.Constants[in.B])
+			}
+			stack = append(stack, value)
+		case STORE, STORE_GLOBAL:
+			slot := in.A
+			if in.Op == STORE {
+				slot += base
+			}
+			value := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			old := m.locals[slot]
+			if old.Kind != UNSET && old.Kind != value.Kind {
+				return m.kindErrorAt(ip, ast.TypeMismatch, "cannot change the type of variable %s", m.program.Constants[in.B])
+			}
+			if old.Kind == ARRAY && old.Elem != value.Elem {
+				return m.kindErrorAt(ip, ast.TypeMismatch, "cannot change the element type of array %s", m.program.Constants[in.B])
+			}
+			m.locals[slot] = value.copied()
+		case CLEAR:
+			slots := m.locals[base+in.A : base+in.A+in.B]
+			for idx := range slots {
+				slots[idx] = Value{}
+			}
+
+		case ADD, SUB, MUL, DIV, MOD:
+			right := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			left := &stack[len(stack)-1].Int
+			value, err := ast.Arithmetic(arithmeticOps[in.Op], *left, right, m.overflow)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			*left = value
+		case NEG:
+			value, err := ast.Negate(stack[len(stack)-1].Int, m.overflow)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack[len(stack)-1].Int = value
+
+		case EQ, NE, LT, LE, GT, GE:
+			right := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			left := stack[len(stack)-1].Int
+			var result bool
+			switch in.Op {
+			case EQ:
+				result = left == right
+			case NE:
+				result = left != right
+			case LT:
+				result = left < right
+			case LE:
+				result = left <= right
+			case GT:
+				result = left > right
+			case GE:
+				result = left >= right
+			}
+			stack[len(stack)-1] = boolValue(result)
+		case STR_EQ, STR_NE:
+			right := stack[len(stack)-1].Str
+			stack = stack[:len(stack)-1]
+			left := stack[len(stack)-1].Str
+			stack[len(stack)-1] = boolValue((left == right) == (in.Op == STR_EQ))
+		case NOT:
+			stack[len(stack)-1].Int ^= 1
+		case XOR:
+			right := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1].Int ^= right
+
+		case JUMP:
+			if in.A <= ip {
+				if err := m.interrupted(ip); err != nil {
+					return err
+				}
+			}
+			ip = in.A - 1
+		case JUMP_IF_FALSE, JUMP_IF_TRUE:
+			value := stack[len(stack)-1].Int == 1
+			stack = stack[:len(stack)-1]
+			if value == (in.Op == JUMP_IF_TRUE) {
+				if in.A <= ip {
+					if err := m.interrupted(ip); err != nil {
+						return err
+					}
+				}
+				ip = in.A - 1
+			}
+
+		case PRINT:
+			values := stack[len(stack)-in.A:]
+			printed := make([]string, len(values))
+			for idx, value := range values {
+				printed[idx] = fmt.Sprint(printArg(value))
+			}
+			stack = stack[:len(stack)-in.A]
+			line := strings.Join(printed, " ")
+			if in.B == 1 {
+				line += "\n"
+			}
+			io.WriteString(m.out, line)
+		case PRINTF:
+			values := stack[len(stack)-in.B:]
+			args := make([]interface{}, len(values))
+			for idx, value := range values {
+				args[idx] = printArg(value)
+			}
+			stack = stack[:len(stack)-in.B]
+			fmt.Fprintf(m.out, m.program.Constants[in.A], args...)
+		case READ_INT:
+			input, err := m.in.Word()
+			if err != nil {
+				return m.kindErrorAt(ip, ast.InputError, "readint: %w", err)
+			}
+			value, err := ast.ParseInt(input)
+			if err != nil {
+				return m.kindErrorAt(ip, ast.InputError, "%w", err)
+			}
+			stack = append(stack, Value{Kind: INT, Int: value})
+		case READ_STR:
+			input, err := m.in.Word()
+			if err != nil {
+				return m.kindErrorAt(ip, ast.InputError, "readstr: %w", err)
+			}
+			stack = append(stack, Value{Kind: STRING, Str: input})
+		case READ_LN:
+			input, err := m.in.Line()
+			if err != nil {
+				return m.kindErrorAt(ip, ast.InputError, "readln: %w", err)
+			}
+			stack = append(stack, Value{Kind: STRING, Str: input})
+		case AT_EOF:
+			stack = append(stack, boolValue(m.in.EOF()))
+
+		case LENGTH:
+			if value := stack[len(stack)-1]; value.Kind == ARRAY {
+				stack[len(stack)-1] = Value{Kind: INT, Int: len(value.Elems)}
+			} else {
+				stack[len(stack)-1] = Value{Kind: INT, Int: ast.LengthOf(value.Str)}
+			}
+		case POSITION:
+			sub := stack[len(stack)-1].Str
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = Value{Kind: INT, Int: ast.PositionOf(stack[len(stack)-1].Str, sub)}
+		case CONCAT:
+			right := stack[len(stack)-1].Str
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1].Str += right
+		case SUBSTRING:
+			args := stack[len(stack)-3:]
+			value := ast.SubstringOf(args[0].Str, args[1].Int, args[2].Int)
+			stack = stack[:len(stack)-2]
+			stack[len(stack)-1] = Value{Kind: STRING, Str: value}
+		case FORMAT_INT:
+			radix := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			value, err := ast.FormatInt(stack[len(stack)-1].Int, radix)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack[len(stack)-1] = Value{Kind: STRING, Str: value}
+		case PARSE_INT:
+			radix := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			value, err := ast.ParseIntRadix(stack[len(stack)-1].Str, radix)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack[len(stack)-1] = Value{Kind: INT, Int: value}
+		case IS_INT:
+			radix := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			value, err := ast.IsInt(stack[len(stack)-1].Str, radix)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack[len(stack)-1] = boolValue(value)
+		case CHR:
+			value, err := ast.Chr(stack[len(stack)-1].Int)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack[len(stack)-1] = Value{Kind: STRING, Str: value}
+		case ORD:
+			value, err := ast.Ord(stack[len(stack)-1].Str)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack[len(stack)-1] = Value{Kind: INT, Int: value}
+		case BUILTIN:
+			values := stack[len(stack)-in.B:]
+			args := make([]interface{}, len(values))
+			for idx, value := range values {
+				args[idx] = builtinArg(value)
+			}
+			stack = stack[:len(stack)-in.B]
+			result, err := ast.Builtins[m.program.Constants[in.A]].Call(args, m.overflow)
+			if err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			stack = append(stack, builtinValue(result))
+
+		case MAKE_ARRAY:
+			array := Value{Kind: ARRAY, Elems: make([]Value, in.A)}
+			copy(array.Elems, stack[len(stack)-in.A:])
+			stack = stack[:len(stack)-in.A]
+			array.Elem = Kind(in.B)
+			stack = append(stack, array)
+		case INDEX:
+			index := stack[len(stack)-1].Int
+			stack = stack[:len(stack)-1]
+			elems := stack[len(stack)-1].Elems
+			if index < 1 || index > len(elems) {
+				return m.errorAt(ip, "%w", &ast.IndexError{Index: index, Size: len(elems)})
+			}
+			stack[len(stack)-1] = elems[index-1]
+		case SET_INDEX:
+			// The array was loaded from its slot, it shares the elements.
+			value, index := stack[len(stack)-1], stack[len(stack)-2].Int
+			elems := stack[len(stack)-3].Elems
+			stack = stack[:len(stack)-3]
+			if index < 1 || index > len(elems) {
+				return m.errorAt(ip, "%w", &ast.IndexError{Index: index, Size: len(elems)})
+			}
+			elems[index-1] = value
+
+		case DECLARE:
+			if m.declared[in.A] {
+				return m.errorAt(ip, "function %s is already declared", m.program.Functions[in.A].Name)
+			}
+			m.declared[in.A] = true
+		case CALL:
+			fn := m.program.Functions[in.A]
+			if !m.declared[in.A] {
+				return m.kindErrorAt(ip, ast.UndefinedFunction, "undefined function: %s", fn.Name)
+			}
+			if len(m.frames) >= maxCallDepth {
+				return m.kindErrorAt(ip, ast.StackOverflow, "call stack exhausted calling %s", fn.Name)
+			}
+			if err := m.interrupted(ip); err != nil {
+				return err
+			}
+			m.frames = append(m.frames, frame{ret: ip, base: base})
+			base = len(m.locals)
+			for idx := 0; idx < fn.Slots; idx++ {
+				m.locals = append(m.locals, Value{})
+			}
+			for idx, arg := range stack[len(stack)-fn.Params:] {
+				m.locals[base+idx] = arg.copied()
+			}
+			stack = stack[:len(stack)-fn.Params]
+			ip = fn.Entry - 1
+		case RETURN, RETURN_VOID:
+			caller := m.frames[len(m.frames)-1]
+			m.frames = m.frames[:len(m.frames)-1]
+			m.locals = m.locals[:base]
+			base, ip = caller.base, caller.ret
+		case NO_RETURN:
+			caller := m.frames[len(m.frames)-1]
+			m.frames = m.frames[:len(m.frames)-1]
+			m.locals = m.locals[:base]
+			return m.errorAt(caller.ret, "function %s ended without returning a value", m.program.Functions[in.A].Name)
+		case POP:
+			stack = stack[:len(stack)-1]
+
+		case HALT:
+			return nil
+		case EXIT:
+			status := stack[len(stack)-1].Int
+			if err := ast.CheckExitStatus(status); err != nil {
+				return m.errorAt(ip, "%w", err)
+			}
+			return &ast.ExitError{Status: status}
+		case FAIL:
+			return m.errorAt(ip, "%s", m.program.Constants[in.A])
+
+		default:
+			return m.errorAt(ip, "vm: unknown opcode %s", in.Op)
+		}
+	}
+}
+
+func boolValue(value bool) Value {
+	if value {
+		return Value{Kind: BOOL, Int: 1}
+	}
+	return Value{Kind: BOOL}
+}
+
+// builtinArg converts a value to an argument of ast.Builtin.Call.
+func builtinArg(value Value) interface{} {
+	switch value.Kind {
+	case INT:
+		return value.Int
+	case BOOL:
+		return value.Int == 1
+	case ARRAY:
+		if value.Elem == INT {
+			elems := make([]int, len(value.Elems))
+			for idx, elem := range value.Elems {
+				elems[idx] = elem.Int
+			}
+			return elems
+		}
+		elems := make([]string, len(value.Elems))
+		for idx, elem := range value.Elems {
+			elems[idx] = elem.Str
+		}
+		return elems
+	}
+	return value.Str
+}
+
+// builtinValue converts the result of ast.Builtin.Call to a value.
+func builtinValue(result interface{}) Value {
+	switch v := result.(type) {
+	case int:
+		return Value{Kind: INT, Int: v}
+	case bool:
+		return boolValue(v)
+	case []int:
+		array := Value{Kind: ARRAY, Elem: INT, Elems: make([]Value, len(v))}
+		for idx, elem := range v {
+			array.Elems[idx] = Value{Kind: INT, Int: elem}
+		}
+		return array
+	case []string:
+		array := Value{Kind: ARRAY, Elem: STRING, Elems: make([]Value, len(v))}
+		for idx, elem := range v {
+			array.Elems[idx] = Value{Kind: STRING, Str: elem}
+		}
+		return array
+	}
+	return Value{Kind: STRING, Str: result.(string)}
+}
+
+// printArg converts a value to what fmt prints the way print does, arrays
+// are formatted already.
+func printArg(value Value) interface{} {
+	switch value.Kind {
+	case INT:
+		return value.Int
+	case BOOL:
+		return value.Int == 1
+	case ARRAY:
+		return formatArray(value)
+	}
+	return value.Str
+}
+
+// formatArray shows an array the way print does.
+func formatArray(array Value) string {
+	elems := make([]string, len(array.Elems))
+	for idx, elem := range array.Elems {
+		if elem.Kind == INT {
+			elems[idx] = strconv.Itoa(elem.Int)
+		} else {
+			elems[idx] = elem.Str
+		}
+	}
+	return ast.FormatArray(elems, array.Elem == STRING)
+}